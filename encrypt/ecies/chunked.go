@@ -0,0 +1,230 @@
+package ecies
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// The chunked encryption splits a stream into chunks of a fixed size that are
+// individually sealed with AES-GCM under a key derived, as in Encrypt, from an
+// ephemeral Diffie-Hellman key. The ciphertext starts with the ephemeral
+// point followed by the sealed chunks. The nonce of each chunk contains its
+// sequence number and a flag marking the last chunk, so that a decrypter
+// detects chunks that were reordered, dropped, or a stream truncated at a
+// chunk boundary.
+
+const (
+	chunkKeyLen   = 32
+	chunkNonceLen = 12
+	chunkTagLen   = 16
+)
+
+// ErrTruncated is returned by a ChunkedDecrypter when the stream ends before
+// its last chunk.
+var ErrTruncated = errors.New("ecies: truncated ciphertext")
+
+// ChunkedEncrypter encrypts a stream of data chunk by chunk. It must be
+// closed to emit the last chunk.
+type ChunkedEncrypter struct {
+	w         io.Writer
+	aead      cipher.AEAD
+	chunkSize int
+	seq       uint64
+	buf       []byte
+	closed    bool
+}
+
+// NewChunkedEncrypter writes the header of a chunked ciphertext for the
+// given public key to w and returns a ChunkedEncrypter writing the sealed
+// chunks of chunkSize bytes of plaintext to w. If the hash input parameter is
+// nil then SHA256 is used as a default.
+func NewChunkedEncrypter(group kyber.Group, public kyber.Point, w io.Writer, chunkSize int, hash func() hash.Hash) (*ChunkedEncrypter, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("ecies: invalid chunk size")
+	}
+	if hash == nil {
+		hash = sha256.New
+	}
+
+	// Generate an ephemeral elliptic curve scalar and point
+	r := group.Scalar().Pick(random.New())
+	R := group.Point().Mul(r, nil)
+
+	aead, err := newChunkAEAD(hash, group.Point().Mul(r, public))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := R.MarshalTo(w); err != nil {
+		return nil, err
+	}
+	return &ChunkedEncrypter{
+		w:         w,
+		aead:      aead,
+		chunkSize: chunkSize,
+		buf:       make([]byte, 0, chunkSize),
+	}, nil
+}
+
+// Write encrypts p. Data is buffered until a full chunk is available and the
+// chunk is only written once it is known not to be the last one.
+func (e *ChunkedEncrypter) Write(p []byte) (int, error) {
+	if e.closed {
+		return 0, errors.New("ecies: write to closed encrypter")
+	}
+	n := len(p)
+	for len(p) > 0 {
+		if len(e.buf) == e.chunkSize {
+			if err := e.seal(false); err != nil {
+				return n - len(p), err
+			}
+		}
+		l := e.chunkSize - len(e.buf)
+		if l > len(p) {
+			l = len(p)
+		}
+		e.buf = append(e.buf, p[:l]...)
+		p = p[l:]
+	}
+	return n, nil
+}
+
+// Close writes the last chunk, which holds the remaining buffered data and
+// can be empty. It does not close the underlying writer.
+func (e *ChunkedEncrypter) Close() error {
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	return e.seal(true)
+}
+
+func (e *ChunkedEncrypter) seal(last bool) error {
+	c := e.aead.Seal(nil, chunkNonce(e.seq, last), e.buf, nil)
+	if _, err := e.w.Write(c); err != nil {
+		return err
+	}
+	e.seq++
+	e.buf = e.buf[:0]
+	return nil
+}
+
+// ChunkedDecrypter decrypts a stream produced by a ChunkedEncrypter.
+type ChunkedDecrypter struct {
+	r         *bufio.Reader
+	aead      cipher.AEAD
+	chunkSize int
+	seq       uint64
+	buf       []byte
+	plain     []byte
+	done      bool
+}
+
+// NewChunkedDecrypter reads the header of a chunked ciphertext from r and
+// returns a ChunkedDecrypter reading the plaintext. The chunk size and hash
+// must be the ones used to encrypt.
+func NewChunkedDecrypter(group kyber.Group, private kyber.Scalar, r io.Reader, chunkSize int, hash func() hash.Hash) (*ChunkedDecrypter, error) {
+	if chunkSize <= 0 {
+		return nil, errors.New("ecies: invalid chunk size")
+	}
+	if hash == nil {
+		hash = sha256.New
+	}
+
+	// Reconstruct the ephemeral elliptic curve point
+	R := group.Point()
+	if _, err := R.UnmarshalFrom(r); err != nil {
+		return nil, err
+	}
+	aead, err := newChunkAEAD(hash, group.Point().Mul(private, R))
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkedDecrypter{
+		r:         bufio.NewReaderSize(r, chunkSize+chunkTagLen+1),
+		aead:      aead,
+		chunkSize: chunkSize,
+		buf:       make([]byte, chunkSize+chunkTagLen),
+	}, nil
+}
+
+// Read reads decrypted data into p. A chunk is only released once it has
+// been authenticated. It returns ErrTruncated if the stream ends before the
+// last chunk, and an error if a chunk fails to authenticate.
+func (d *ChunkedDecrypter) Read(p []byte) (int, error) {
+	for len(d.plain) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.open(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.plain)
+	d.plain = d.plain[n:]
+	return n, nil
+}
+
+func (d *ChunkedDecrypter) open() error {
+	n, err := io.ReadFull(d.r, d.buf)
+	switch err {
+	case nil, io.ErrUnexpectedEOF:
+	case io.EOF:
+		return ErrTruncated
+	default:
+		return err
+	}
+	// a chunk is the last one if nothing follows it
+	last := err == io.ErrUnexpectedEOF
+	if !last {
+		if _, err := d.r.Peek(1); err == io.EOF {
+			last = true
+		} else if err != nil {
+			return err
+		}
+	}
+	plain, err := d.aead.Open(d.buf[:0], chunkNonce(d.seq, last), d.buf[:n], nil)
+	if err != nil {
+		if last {
+			// a non-final chunk followed by nothing
+			return ErrTruncated
+		}
+		return err
+	}
+	d.seq++
+	d.plain = plain
+	d.done = last
+	return nil
+}
+
+func newChunkAEAD(hash func() hash.Hash, dh kyber.Point) (cipher.AEAD, error) {
+	key, err := deriveKey(hash, dh, chunkKeyLen)
+	if err != nil {
+		return nil, err
+	}
+	aes, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(aes)
+}
+
+// chunkNonce returns the nonce of the chunk with the given sequence number:
+// the big-endian sequence number followed by a byte set to 1 for the last
+// chunk.
+func chunkNonce(seq uint64, last bool) []byte {
+	nonce := make([]byte, chunkNonceLen)
+	binary.BigEndian.PutUint64(nonce[chunkNonceLen-9:], seq)
+	if last {
+		nonce[chunkNonceLen-1] = 1
+	}
+	return nonce
+}
@@ -0,0 +1,93 @@
+package ecies
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func chunkedEncrypt(t *testing.T, message []byte, chunkSize int) ([]byte, func([]byte) ([]byte, error)) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+
+	var ctx bytes.Buffer
+	enc, err := NewChunkedEncrypter(suite, public, &ctx, chunkSize, nil)
+	require.NoError(t, err)
+	_, err = io.Copy(enc, bytes.NewReader(message))
+	require.NoError(t, err)
+	require.NoError(t, enc.Close())
+
+	decrypt := func(c []byte) ([]byte, error) {
+		dec, err := NewChunkedDecrypter(suite, private, bytes.NewReader(c), chunkSize, nil)
+		if err != nil {
+			return nil, err
+		}
+		return ioutil.ReadAll(dec)
+	}
+	return ctx.Bytes(), decrypt
+}
+
+func TestECIESChunked(t *testing.T) {
+	chunkSize := 64 * 1024
+	for _, size := range []int{0, 1, chunkSize, 5 * 1024 * 1024, 5*1024*1024 + 17} {
+		message := make([]byte, size)
+		_, err := rand.Read(message)
+		require.NoError(t, err)
+
+		ctx, decrypt := chunkedEncrypt(t, message, chunkSize)
+		plaintext, err := decrypt(ctx)
+		require.NoError(t, err)
+		require.Equal(t, message, plaintext)
+	}
+}
+
+func TestECIESChunkedTampering(t *testing.T) {
+	chunkSize := 64 * 1024
+	message := make([]byte, 5*1024*1024)
+	_, err := rand.Read(message)
+	require.NoError(t, err)
+	ctx, decrypt := chunkedEncrypt(t, message, chunkSize)
+
+	header := edwards25519.NewBlakeSHA256Ed25519().PointLen()
+	sealed := chunkSize + chunkTagLen
+	// the last chunk holds the last full chunk of data
+	require.Equal(t, 80*sealed, len(ctx)-header)
+
+	// missing last chunk
+	_, err = decrypt(ctx[:len(ctx)-sealed])
+	require.Equal(t, ErrTruncated, err)
+
+	// missing header
+	_, err = decrypt(ctx[:header-1])
+	require.Error(t, err)
+
+	// truncated at any chunk boundary
+	_, err = decrypt(ctx[:header+3*sealed])
+	require.Equal(t, ErrTruncated, err)
+
+	// truncated in the middle of a chunk
+	_, err = decrypt(ctx[:header+3*sealed+100])
+	require.Error(t, err)
+
+	// reordered chunks
+	reordered := make([]byte, len(ctx))
+	copy(reordered, ctx)
+	copy(reordered[header:header+sealed], ctx[header+sealed:header+2*sealed])
+	copy(reordered[header+sealed:header+2*sealed], ctx[header:header+sealed])
+	_, err = decrypt(reordered)
+	require.Error(t, err)
+
+	// modified chunk
+	modified := make([]byte, len(ctx))
+	copy(modified, ctx)
+	modified[header+10] ^= 0xff
+	_, err = decrypt(modified)
+	require.Error(t, err)
+}
@@ -21,12 +21,38 @@ import (
 // containing the ephemeral elliptic curve point of the DH key exchange and the
 // ciphertext or an error.
 func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func() hash.Hash) ([]byte, error) {
+	return EncryptWithPadding(group, public, message, hash, PaddingNone)
+}
+
+// EncryptWithPadding is like Encrypt but pads the message with the given
+// padding scheme before encrypting it. The ciphertext must be decrypted with
+// DecryptWithPadding and the same padding.
+func EncryptWithPadding(group kyber.Group, public kyber.Point, message []byte, hash func() hash.Hash, padding Padding) ([]byte, error) {
+	// Generate an ephemeral elliptic curve scalar
+	r := group.Scalar().Pick(random.New())
+	return encrypt(group, public, message, hash, padding, r)
+}
+
+// EncryptWithEphemeral is like Encrypt but uses the given ephemeral scalar
+// instead of a random one, so that the ciphertext is deterministic, for
+// example to produce test vectors. WARNING: it must never be used in
+// production. Encrypting two messages with the same ephemeral scalar, to the
+// same public key, reuses the AES-GCM key and nonce, which reveals the XOR
+// of the messages and allows forgeries.
+func EncryptWithEphemeral(group kyber.Group, public kyber.Point, message []byte, hash func() hash.Hash, ephemeral kyber.Scalar) ([]byte, error) {
+	return encrypt(group, public, message, hash, PaddingNone, ephemeral)
+}
+
+func encrypt(group kyber.Group, public kyber.Point, message []byte, hash func() hash.Hash, padding Padding, r kyber.Scalar) ([]byte, error) {
+	message, err := padding.pad(message)
+	if err != nil {
+		return nil, err
+	}
 	if hash == nil {
 		hash = sha256.New
 	}
 
-	// Generate an ephemeral elliptic curve scalar and point
-	r := group.Scalar().Pick(random.New())
+	// Compute the ephemeral elliptic curve point
 	R := group.Point().Mul(r, nil)
 
 	// Compute shared DH key
@@ -75,6 +101,12 @@ func Encrypt(group kyber.Group, public kyber.Point, message []byte, hash func()
 // input parameter is nil then SHA256 is used as a default. Decrypt returns the
 // plaintext message or an error.
 func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() hash.Hash) ([]byte, error) {
+	return DecryptWithPadding(group, private, ctx, hash, PaddingNone)
+}
+
+// DecryptWithPadding is like Decrypt but removes the given padding from the
+// decrypted message. It returns an error if the padding is malformed.
+func DecryptWithPadding(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() hash.Hash, padding Padding) ([]byte, error) {
 	if hash == nil {
 		hash = sha256.New
 	}
@@ -105,7 +137,11 @@ func Decrypt(group kyber.Group, private kyber.Scalar, ctx []byte, hash func() ha
 	if err != nil {
 		return nil, err
 	}
-	return aesgcm.Open(nil, nonce, ctx[l:], nil)
+	message, err := aesgcm.Open(nil, nonce, ctx[l:], nil)
+	if err != nil {
+		return nil, err
+	}
+	return padding.unpad(message)
 }
 
 func deriveKey(hash func() hash.Hash, dh kyber.Point, len int) ([]byte, error) {
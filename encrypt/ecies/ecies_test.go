@@ -2,6 +2,7 @@ package ecies
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -49,6 +50,27 @@ func TestECIESFailCiphertext(t *testing.T) {
 	require.NotNil(t, err)
 }
 
+func TestECIESWithEphemeral(t *testing.T) {
+	message := []byte("Hello ECIES")
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().SetInt64(42)
+	public := suite.Point().Mul(private, nil)
+	ephemeral := suite.Scalar().SetInt64(7)
+
+	ciphertext, err := EncryptWithEphemeral(suite, public, message, nil, ephemeral)
+	require.NoError(t, err)
+	expected, _ := hex.DecodeString("b862409fb5c4c4123df2abf7462b88f041ad36dd6864ce872fd5472be363c5b1" +
+		"6b67a8cf520dc02eeb6f9b2921c4eaa1bba8f52faaefab6da7a2e3")
+	require.Equal(t, expected, ciphertext)
+	R := suite.Point().Mul(ephemeral, nil)
+	buf, _ := R.MarshalBinary()
+	require.Equal(t, buf, ciphertext[:suite.PointLen()])
+
+	plaintext, err := Decrypt(suite, private, ciphertext, nil)
+	require.NoError(t, err)
+	require.Equal(t, message, plaintext)
+}
+
 func BenchmarkECIES(b *testing.B) {
 	suites := []struct {
 		kyber.Group
@@ -0,0 +1,62 @@
+package ecies
+
+import (
+	"crypto/aes"
+	"errors"
+)
+
+// Padding selects how a message is padded before it is encrypted.
+type Padding int
+
+const (
+	// PaddingNone leaves the message as is. It is the padding of Encrypt,
+	// since AES-GCM works as a stream cipher.
+	PaddingNone Padding = iota
+	// PaddingPKCS7 pads the message to a multiple of the AES block size as
+	// in PKCS#7 (RFC 5652, section 6.3). A full block of padding is added to
+	// a message whose length is already a multiple of the block size. It
+	// hides the exact length of the message.
+	PaddingPKCS7
+)
+
+// errPadding is returned when a decrypted message has an invalid padding.
+var errPadding = errors.New("ecies: invalid padding")
+
+func (p Padding) pad(message []byte) ([]byte, error) {
+	switch p {
+	case PaddingNone:
+		return message, nil
+	case PaddingPKCS7:
+		n := aes.BlockSize - len(message)%aes.BlockSize
+		padded := make([]byte, len(message)+n)
+		copy(padded, message)
+		for i := len(message); i < len(padded); i++ {
+			padded[i] = byte(n)
+		}
+		return padded, nil
+	}
+	return nil, errors.New("ecies: unknown padding")
+}
+
+func (p Padding) unpad(padded []byte) ([]byte, error) {
+	switch p {
+	case PaddingNone:
+		return padded, nil
+	case PaddingPKCS7:
+		l := len(padded)
+		if l == 0 || l%aes.BlockSize != 0 {
+			return nil, errPadding
+		}
+		n := int(padded[l-1])
+		if n == 0 || n > aes.BlockSize {
+			return nil, errPadding
+		}
+		for _, b := range padded[l-n:] {
+			if int(b) != n {
+				return nil, errPadding
+			}
+		}
+		return padded[:l-n], nil
+	}
+	return nil, errors.New("ecies: unknown padding")
+}
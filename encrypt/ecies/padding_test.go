@@ -0,0 +1,67 @@
+package ecies
+
+import (
+	"bytes"
+	"crypto/aes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestECIESPadding(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	private := suite.Scalar().Pick(random.New())
+	public := suite.Point().Mul(private, nil)
+	overhead := suite.PointLen() + 16
+
+	for _, l := range []int{0, 1, aes.BlockSize - 1, aes.BlockSize, aes.BlockSize + 1, 3 * aes.BlockSize} {
+		message := random.Bits(uint(8*l), false, random.New())
+
+		ctx, err := EncryptWithPadding(suite, public, message, nil, PaddingPKCS7)
+		require.NoError(t, err)
+		// a full block of padding is added to an aligned message
+		require.Equal(t, overhead+(l/aes.BlockSize+1)*aes.BlockSize, len(ctx), l)
+		plain, err := DecryptWithPadding(suite, private, ctx, nil, PaddingPKCS7)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(message, plain), l)
+
+		ctx, err = EncryptWithPadding(suite, public, message, nil, PaddingNone)
+		require.NoError(t, err)
+		require.Equal(t, overhead+l, len(ctx), l)
+		plain, err = DecryptWithPadding(suite, private, ctx, nil, PaddingNone)
+		require.NoError(t, err)
+		require.True(t, bytes.Equal(message, plain), l)
+		if l%aes.BlockSize != 0 {
+			// not a padded message
+			_, err = DecryptWithPadding(suite, private, ctx, nil, PaddingPKCS7)
+			require.Error(t, err, l)
+		}
+	}
+}
+
+func TestECIESPaddingMalformed(t *testing.T) {
+	block := func(last ...byte) []byte {
+		b := make([]byte, aes.BlockSize)
+		copy(b[aes.BlockSize-len(last):], last)
+		return b
+	}
+	for _, padded := range [][]byte{
+		nil,
+		make([]byte, aes.BlockSize-1),
+		block(0),
+		block(aes.BlockSize + 1),
+		block(1, 3, 3),
+		block(4, 4, 4),
+	} {
+		_, err := PaddingPKCS7.unpad(padded)
+		require.Equal(t, errPadding, err)
+	}
+	plain, err := PaddingPKCS7.unpad(block(3, 3, 3))
+	require.NoError(t, err)
+	require.Len(t, plain, aes.BlockSize-3)
+
+	_, err = Padding(42).pad([]byte("message"))
+	require.Error(t, err)
+}
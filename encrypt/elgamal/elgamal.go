@@ -0,0 +1,39 @@
+// Package elgamal implements ElGamal encryption of group elements, whose
+// ciphertexts can be re-randomized, as needed to prepare the input of a
+// mixnet such as the one of the shuffle package.
+package elgamal
+
+import (
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// Encrypt encrypts the point M for the public key, returning the ciphertext
+// (K, C) = (k*B, M + k*public) for a fresh random scalar k. A message can be
+// embedded into M with Point.Embed.
+func Encrypt(group kyber.Group, public, M kyber.Point) (K, C kyber.Point) {
+	k := group.Scalar().Pick(random.New())
+	K = group.Point().Mul(k, nil)
+	C = group.Point().Mul(k, public)
+	C.Add(C, M)
+	return K, C
+}
+
+// Decrypt returns the point encrypted in the ciphertext (K, C) with the
+// private key.
+func Decrypt(group kyber.Group, private kyber.Scalar, K, C kyber.Point) kyber.Point {
+	S := group.Point().Mul(private, K)
+	return S.Sub(C, S)
+}
+
+// ReRandomize returns a new ciphertext (K + r*B, C + r*public) for a fresh
+// random scalar r. It decrypts to the same point as (K, C), but can not be
+// linked to it without the private key.
+func ReRandomize(group kyber.Group, public, K, C kyber.Point) (K2, C2 kyber.Point) {
+	r := group.Scalar().Pick(random.New())
+	K2 = group.Point().Mul(r, nil)
+	K2.Add(K2, K)
+	C2 = group.Point().Mul(r, public)
+	C2.Add(C2, C)
+	return K2, C2
+}
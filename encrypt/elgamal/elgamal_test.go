@@ -0,0 +1,35 @@
+package elgamal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/util/key"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestReRandomize(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	kp := key.NewKeyPair(suite)
+	msg := []byte("mixnet input")
+	M := suite.Point().Embed(msg, random.New())
+
+	K, C := Encrypt(suite, kp.Public, M)
+	require.True(t, M.Equal(Decrypt(suite, kp.Private, K, C)))
+
+	K2, C2 := ReRandomize(suite, kp.Public, K, C)
+	require.False(t, K.Equal(K2))
+	require.False(t, C.Equal(C2))
+	M2 := Decrypt(suite, kp.Private, K2, C2)
+	require.True(t, M.Equal(M2))
+	data, err := M2.Data()
+	require.NoError(t, err)
+	require.Equal(t, msg, data)
+
+	// re-randomizing twice gives different ciphertexts of the same point
+	K3, C3 := ReRandomize(suite, kp.Public, K, C)
+	require.False(t, K2.Equal(K3))
+	require.False(t, C2.Equal(C3))
+	require.True(t, M.Equal(Decrypt(suite, kp.Private, K3, C3)))
+}
@@ -0,0 +1,93 @@
+// Package tkem implements a (t,n)-threshold key encapsulation mechanism on
+// top of a pairing. The n participants hold shares xi of a secret x, for
+// example from a DKG (see kyber/share/dkg), and X = x * G2 is their group
+// public key. Anyone can encapsulate a fresh symmetric key to X. Decapsulating
+// it requires at least t participants to each compute a decryption share
+// from the ciphertext with their own share xi. Every decryption share can be
+// verified against the public key share Xi = xi * G2 of its issuer, given by
+// the public sharing polynomial.
+//
+// The ciphertext is a point U = r * G1 and the key is derived by hashing U
+// together with e(U, X) = e(G1, G2)^(r*x). The ciphertext is not
+// authenticated: the scheme protects the key against passive adversaries
+// only, and the key should be used with an authenticated cipher.
+package tkem
+
+import (
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+// Encapsulate picks a fresh symmetric key for the group public key X, a
+// point on G2. It returns the ciphertext U, a point on G1 to be sent to the
+// participants, and the key, whose length is the size of suite's hash.
+func Encapsulate(suite pairing.Suite, public kyber.Point) (kyber.Point, []byte, error) {
+	r := suite.G1().Scalar().Pick(suite.RandomStream())
+	U := suite.G1().Point().Mul(r, nil)
+	key, err := deriveKey(suite, U, suite.Pair(U, public))
+	if err != nil {
+		return nil, nil, err
+	}
+	return U, key, nil
+}
+
+// DecapsulateShare computes the decryption share xi * U of the ciphertext U
+// with the private share xi.
+func DecapsulateShare(suite pairing.Suite, private *share.PriShare, U kyber.Point) *share.PubShare {
+	return &share.PubShare{I: private.I, V: suite.G1().Point().Mul(private.V, U)}
+}
+
+// VerifyShare checks that the decryption share ds of the ciphertext U was
+// computed with the private share matching the public key share given by
+// public at the index of ds, by checking that e(ds, G2) == e(U, Xi).
+func VerifyShare(suite pairing.Suite, public *share.PubPoly, U kyber.Point, ds *share.PubShare) error {
+	left := suite.Pair(ds.V, suite.G2().Point().Base())
+	right := suite.Pair(U, public.Eval(ds.I).V)
+	if !left.Equal(right) {
+		return errors.New("tkem: invalid decryption share")
+	}
+	return nil
+}
+
+// CombineShares verifies the decryption shares of the ciphertext U, recovers
+// x * U from t of them using Lagrange interpolation and returns the key that
+// was encapsulated in U. Shares with the index of a previous share are
+// skipped. An error is returned if one of the shares is invalid or if fewer
+// than t shares of distinct indices are given.
+func CombineShares(suite pairing.Suite, public *share.PubPoly, U kyber.Point, shares []*share.PubShare, t, n int) ([]byte, error) {
+	valid := make([]*share.PubShare, 0, t)
+	seen := make(map[int]bool)
+	for _, ds := range shares {
+		if seen[ds.I] {
+			continue
+		}
+		if err := VerifyShare(suite, public, U, ds); err != nil {
+			return nil, err
+		}
+		seen[ds.I] = true
+		valid = append(valid, ds)
+		if len(valid) >= t {
+			break
+		}
+	}
+	xU, err := share.RecoverCommit(suite.G1(), valid, t, n)
+	if err != nil {
+		return nil, err
+	}
+	// e(x * U, G2) = e(U, X)
+	return deriveKey(suite, U, suite.Pair(xU, suite.G2().Point().Base()))
+}
+
+func deriveKey(suite pairing.Suite, U, shared kyber.Point) ([]byte, error) {
+	h := suite.Hash()
+	if _, err := U.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	if _, err := shared.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
@@ -0,0 +1,60 @@
+package tkem
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+func TestTKEM(test *testing.T) {
+	suite := bn256.NewSuite()
+	n := 10
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	priShares := priPoly.Shares(n)
+
+	U, key, err := Encapsulate(suite, pubPoly.Commit())
+	require.NoError(test, err)
+	require.Len(test, key, suite.Hash().Size())
+
+	shares := make([]*share.PubShare, n)
+	for i, x := range priShares {
+		shares[i] = DecapsulateShare(suite, x, U)
+		require.NoError(test, VerifyShare(suite, pubPoly, U, shares[i]))
+	}
+
+	// any t shares recover the key
+	recovered, err := CombineShares(suite, pubPoly, U, shares[:t], t, n)
+	require.NoError(test, err)
+	require.Equal(test, key, recovered)
+	recovered, err = CombineShares(suite, pubPoly, U, shares[n-t:], t, n)
+	require.NoError(test, err)
+	require.Equal(test, key, recovered)
+
+	// but not fewer
+	_, err = CombineShares(suite, pubPoly, U, shares[:t-1], t, n)
+	require.Error(test, err)
+
+	// duplicated shares count once
+	dup := append([]*share.PubShare{shares[0]}, shares[:t]...)
+	recovered, err = CombineShares(suite, pubPoly, U, dup, t, n)
+	require.NoError(test, err)
+	require.Equal(test, key, recovered)
+	dup = append(shares[:t-1:t-1], shares[0])
+	_, err = CombineShares(suite, pubPoly, U, dup, t, n)
+	require.Error(test, err)
+
+	// a share computed with the wrong private share is rejected
+	wrong := DecapsulateShare(suite, &share.PriShare{I: 0, V: priShares[1].V}, U)
+	require.Error(test, VerifyShare(suite, pubPoly, U, wrong))
+	_, err = CombineShares(suite, pubPoly, U, append([]*share.PubShare{wrong}, shares[1:t]...), t, n)
+	require.Error(test, err)
+
+	// another encapsulation gives another key
+	_, key2, err := Encapsulate(suite, pubPoly.Commit())
+	require.NoError(test, err)
+	require.NotEqual(test, key, key2)
+}
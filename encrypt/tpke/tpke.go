@@ -0,0 +1,109 @@
+// Package tpke implements a (t,n)-threshold public-key encryption scheme
+// that combines the threshold key encapsulation of package encrypt/tkem with
+// AES-GCM. A message is encrypted to the group public key X = x * G2 of n
+// participants holding shares of x, for example from a DKG. Decrypting it
+// requires t of them to each compute a decryption share of the ciphertext,
+// which anyone can check against the public sharing polynomial before
+// combining the shares.
+//
+// The additional data given to Encrypt is authenticated along with the
+// encapsulation point, so that changing either of them or the encrypted
+// message makes Decrypt fail. As with tkem, a decryption share reveals the
+// key of the ciphertext it was computed for, so participants should only
+// issue shares for ciphertexts they agree to decrypt.
+package tpke
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/encrypt/tkem"
+	"go.dedis.ch/kyber/v3/pairing"
+	"go.dedis.ch/kyber/v3/share"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Ciphertext is a message encrypted to a group public key.
+type Ciphertext struct {
+	// U is the encapsulation of the key, a point on G1.
+	U kyber.Point
+	// AAD is the additional data authenticated with the message.
+	AAD []byte
+	// C is the AES-GCM encryption of the message.
+	C []byte
+}
+
+// Encrypt encrypts msg to the group public key X, a point on G2, and
+// authenticates aad along with it.
+func Encrypt(suite pairing.Suite, public kyber.Point, msg, aad []byte) (*Ciphertext, error) {
+	U, key, err := tkem.Encapsulate(suite, public)
+	if err != nil {
+		return nil, err
+	}
+	ct := &Ciphertext{U: U, AAD: aad}
+	aead, ad, err := ct.aead(suite, key)
+	if err != nil {
+		return nil, err
+	}
+	ct.C = aead.Seal(nil, make([]byte, aead.NonceSize()), msg, ad)
+	return ct, nil
+}
+
+// DecryptShare returns the decryption share of the ciphertext computed with
+// the private share of a participant. Its correctness can be checked with
+// VerifyShare.
+func DecryptShare(suite pairing.Suite, private *share.PriShare, ct *Ciphertext) *share.PubShare {
+	return tkem.DecapsulateShare(suite, private, ct.U)
+}
+
+// VerifyShare checks that the decryption share ds of the ciphertext was
+// computed with the private share matching the public polynomial at the
+// index of ds.
+func VerifyShare(suite pairing.Suite, public *share.PubPoly, ct *Ciphertext, ds *share.PubShare) error {
+	return tkem.VerifyShare(suite, public, ct.U, ds)
+}
+
+// Decrypt verifies the decryption shares, combines t of them, where t is
+// the threshold of the public polynomial, and returns the decrypted
+// message. It returns an error if a share is invalid, if there are fewer
+// than t shares, or if the ciphertext or its additional data was modified.
+func Decrypt(suite pairing.Suite, public *share.PubPoly, ct *Ciphertext, shares []*share.PubShare) ([]byte, error) {
+	key, err := tkem.CombineShares(suite, public, ct.U, shares, public.Threshold(), len(shares))
+	if err != nil {
+		return nil, err
+	}
+	aead, ad, err := ct.aead(suite, key)
+	if err != nil {
+		return nil, err
+	}
+	msg, err := aead.Open(nil, make([]byte, aead.NonceSize()), ct.C, ad)
+	if err != nil {
+		return nil, errors.New("tpke: invalid ciphertext")
+	}
+	return msg, nil
+}
+
+// aead returns the AES-GCM cipher keyed by the encapsulated key and the
+// additional data of the ciphertext, which covers U and AAD. Since every
+// encryption uses a fresh key, the nonce can be fixed.
+func (ct *Ciphertext) aead(suite pairing.Suite, key []byte) (cipher.AEAD, []byte, error) {
+	aesKey := make([]byte, 32)
+	if _, err := hkdf.New(suite.Hash, key, nil, []byte("tpke")).Read(aesKey); err != nil {
+		return nil, nil, err
+	}
+	block, err := aes.NewCipher(aesKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, nil, err
+	}
+	ad, err := ct.U.MarshalBinary()
+	if err != nil {
+		return nil, nil, err
+	}
+	return aead, append(ad, ct.AAD...), nil
+}
@@ -0,0 +1,58 @@
+package tpke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+func TestTPKE(test *testing.T) {
+	suite := bn256.NewSuite()
+	n := 7
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	priShares := priPoly.Shares(n)
+
+	msg := []byte("threshold public-key encryption")
+	aad := []byte("epoch 42")
+	ct, err := Encrypt(suite, pubPoly.Commit(), msg, aad)
+	require.NoError(test, err)
+
+	shares := make([]*share.PubShare, n)
+	for i, x := range priShares {
+		shares[i] = DecryptShare(suite, x, ct)
+		require.NoError(test, VerifyShare(suite, pubPoly, ct, shares[i]))
+	}
+
+	// any t shares decrypt
+	plain, err := Decrypt(suite, pubPoly, ct, shares[:t])
+	require.NoError(test, err)
+	require.Equal(test, msg, plain)
+	plain, err = Decrypt(suite, pubPoly, ct, shares[n-t:])
+	require.NoError(test, err)
+	require.Equal(test, msg, plain)
+
+	// but not fewer
+	_, err = Decrypt(suite, pubPoly, ct, shares[:t-1])
+	require.Error(test, err)
+
+	// a wrong share is detected
+	wrong := DecryptShare(suite, &share.PriShare{I: 0, V: priShares[1].V}, ct)
+	require.Error(test, VerifyShare(suite, pubPoly, ct, wrong))
+	_, err = Decrypt(suite, pubPoly, ct, append([]*share.PubShare{wrong}, shares[1:t]...))
+	require.Error(test, err)
+
+	// tampering with the additional data or the message is detected
+	tampered := *ct
+	tampered.AAD = []byte("epoch 43")
+	_, err = Decrypt(suite, pubPoly, &tampered, shares[:t])
+	require.Error(test, err)
+	tampered = *ct
+	tampered.C = append([]byte{}, ct.C...)
+	tampered.C[0] ^= 1
+	_, err = Decrypt(suite, pubPoly, &tampered, shares[:t])
+	require.Error(test, err)
+}
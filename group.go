@@ -2,6 +2,7 @@ package kyber
 
 import (
 	"crypto/cipher"
+	"math/big"
 )
 
 // Scalar represents a scalar value by which
@@ -99,9 +100,12 @@ type Point interface {
 	Data() ([]byte, error)
 
 	// Add points so that their scalars add homomorphically.
+	// The receiver may be one of the arguments, so that acc.Add(acc, p)
+	// accumulates a sum in place.
 	Add(a, b Point) Point
 
 	// Subtract points so that their scalars subtract homomorphically.
+	// As with Add, the receiver may be one of the arguments.
 	Sub(a, b Point) Point
 
 	// Set to the negation of point a.
@@ -124,6 +128,43 @@ type AllowsVarTime interface {
 	AllowVarTime(bool)
 }
 
+// VarTimeMultiplier is an optional interface that a Point can implement to
+// offer a scalar multiplication that is faster than Mul but whose running
+// time depends on the scalar. WARNING: MulVarTime leaks the scalar through a
+// timing side channel. It must only be used with public scalars, such as
+// the ones of a signature verification, and never with secret keys, nonces
+// or shares.
+type VarTimeMultiplier interface {
+	// MulVarTime sets the receiver to s*p, or to s times the standard base
+	// point if p is nil, and returns it.
+	MulVarTime(s Scalar, p Point) Point
+}
+
+// PointGenerator is an optional interface that a Group can implement to
+// produce points whose discrete logarithm with respect to the base point is
+// unknown, e.g. to derive an independent "nothing-up-my-sleeve" generator.
+type PointGenerator interface {
+	// RandomPoint returns a fresh point picked from rand.
+	RandomPoint(rand cipher.Stream) Point
+
+	// HashToPoint deterministically maps data to a point of the group.
+	HashToPoint(data []byte) Point
+}
+
+// GroupParameters is an optional interface that a Group can implement to
+// expose its parameters to generic code. The points of the group form a
+// subgroup of prime order Order() of a larger group, such as the points of
+// an elliptic curve, whose size is Order() times Cofactor(). The returned
+// values must not be modified.
+type GroupParameters interface {
+	// Order returns the prime order of the group, i.e. of its scalars.
+	Order() *big.Int
+
+	// Cofactor returns the ratio between the size of the larger group and
+	// Order, which is 1 for prime-order curves.
+	Cofactor() *big.Int
+}
+
 // Group interface represents a mathematical group
 // usable for Diffie-Hellman key exchange, ElGamal encryption,
 // and the related body of public-key cryptographic algorithms
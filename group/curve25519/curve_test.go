@@ -45,6 +45,10 @@ func TestE521(t *testing.T) {
 	test.GroupTest(t, new(ExtendedCurve).Init(ParamE521(), false))
 }
 
+func TestEd448(t *testing.T) {
+	test.GroupTest(t, new(ExtendedCurve).Init(ParamEd448(), false))
+}
+
 func TestSetBytesBE(t *testing.T) {
 	g := new(ExtendedCurve).Init(ParamE521(), false)
 	s := g.Scalar()
@@ -157,3 +157,21 @@ func ParamE521() *Param {
 	p.PBY.SetString("12", 10)
 	return &p
 }
+
+// ParamEd448 defines the edwards448 "Goldilocks" curve used by Ed448, as
+// specified in RFC 7748 and RFC 8032. It is an untwisted Edwards curve, with
+// a=1, over the field of order 2^448 - 2^224 - 1.
+func ParamEd448() *Param {
+	var p Param
+	var qs big.Int
+	p.Name = "Ed448"
+	p.P.SetBit(zero, 448, 1).Sub(&p.P, new(big.Int).SetBit(zero, 224, 1)).Sub(&p.P, one)
+	qs.SetString("13818066809895115352007386748515426880336692474882178609894547503885", 10)
+	p.Q.SetBit(zero, 446, 1).Sub(&p.Q, &qs)
+	p.R = 4
+	p.A.SetInt64(1)
+	p.D.SetInt64(-39081)
+	p.PBX.SetString("224580040295924300187604334099896036246789641632564134246125461686950415467406032909029192869357953282578032075146446173674602635247710", 10)
+	p.PBY.SetString("298819210078481492676017930443930673437544040154080242095928241372331506189835876003536878655418784733982303233503462500531545062832660", 10)
+	return &p
+}
@@ -0,0 +1,56 @@
+// Package ed448 implements the prime-order subgroup of the edwards448
+// "Goldilocks" curve of RFC 7748, on which the Ed448 signatures of RFC 8032
+// are defined.
+//
+// Points are encoded on 57 bytes as specified in RFC 8032: the little-endian
+// y-coordinate followed by a byte holding the sign of the x-coordinate.
+// Scalars are encoded on 56 bytes in little-endian order.
+//
+// The arithmetic is provided by the generic extended-coordinates
+// implementation of package curve25519 and uses variable time algorithms.
+package ed448
+
+import (
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/curve25519"
+	"go.dedis.ch/kyber/v3/group/mod"
+)
+
+var param = curve25519.ParamEd448()
+
+var cofactor = big.NewInt(int64(param.R))
+
+// Curve represents the prime-order subgroup of edwards448. It must be
+// created with NewCurve.
+type Curve struct {
+	curve25519.ExtendedCurve
+}
+
+// NewCurve returns the prime-order subgroup of edwards448, with the base
+// point of RFC 8032.
+func NewCurve() *Curve {
+	c := new(Curve)
+	c.Init(param, false)
+	return c
+}
+
+// Scalar creates a new scalar modulo the prime order of the group. Unlike
+// the scalars of package curve25519, its SetBytes and binary encoding use
+// the little-endian byte order of RFC 8032.
+func (c *Curve) Scalar() kyber.Scalar {
+	return mod.NewIntBytes(nil, &param.Q, mod.LittleEndian)
+}
+
+// Order returns the prime order of the group. It implements the
+// kyber.GroupParameters interface.
+func (c *Curve) Order() *big.Int {
+	return &param.Q
+}
+
+// Cofactor returns 4, the cofactor of edwards448. It implements the
+// kyber.GroupParameters interface.
+func (c *Curve) Cofactor() *big.Int {
+	return cofactor
+}
@@ -0,0 +1,29 @@
+package ed448
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/test"
+)
+
+var tSuite = NewBlakeSHA512Ed448()
+
+func TestSuite(t *testing.T) { test.SuiteTest(t, tSuite) }
+
+func TestGroupParameters(t *testing.T) {
+	var params kyber.GroupParameters = NewCurve()
+	require.Equal(t, int64(4), params.Cofactor().Int64())
+	require.Equal(t, 446, params.Order().BitLen())
+	require.Equal(t, 57, tSuite.PointLen())
+	require.Equal(t, 56, tSuite.ScalarLen())
+}
+
+func TestScalarLittleEndian(t *testing.T) {
+	s := tSuite.Scalar().SetInt64(1)
+	buf, err := s.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(1), buf[0])
+	require.True(t, tSuite.Scalar().SetBytes([]byte{1}).Equal(s))
+}
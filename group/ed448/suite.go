@@ -0,0 +1,72 @@
+package ed448
+
+import (
+	"crypto/cipher"
+	"crypto/sha512"
+	"hash"
+	"io"
+	"reflect"
+
+	"go.dedis.ch/fixbuf"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/internal/marshalling"
+	"go.dedis.ch/kyber/v3/util/random"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
+)
+
+// SuiteEd448 implements the Group, HashFactory, XOFFactory, Encoding and
+// Random interfaces for edwards448.
+type SuiteEd448 struct {
+	Curve
+	r cipher.Stream
+}
+
+// Hash returns a newly instantiated SHA-512 hash function.
+func (s *SuiteEd448) Hash() hash.Hash {
+	return sha512.New()
+}
+
+// XOF returns an XOF which is implemented via the Blake2b hash.
+func (s *SuiteEd448) XOF(key []byte) kyber.XOF {
+	return blake2xb.New(key)
+}
+
+func (s *SuiteEd448) Read(r io.Reader, objs ...interface{}) error {
+	return fixbuf.Read(r, s, objs...)
+}
+
+func (s *SuiteEd448) Write(w io.Writer, objs ...interface{}) error {
+	return fixbuf.Write(w, objs)
+}
+
+// New implements the kyber.Encoding interface
+func (s *SuiteEd448) New(t reflect.Type) interface{} {
+	return marshalling.GroupNew(s, t)
+}
+
+// RandomStream returns a cipher.Stream that returns a key stream
+// from crypto/rand.
+func (s *SuiteEd448) RandomStream() cipher.Stream {
+	if s.r != nil {
+		return s.r
+	}
+	return random.New()
+}
+
+// NewBlakeSHA512Ed448 returns a cipher suite based on package
+// go.dedis.ch/kyber/v3/xof/blake2xb, SHA-512, and the edwards448 curve.
+// It produces cryptographically random numbers via package crypto/rand.
+func NewBlakeSHA512Ed448() *SuiteEd448 {
+	suite := new(SuiteEd448)
+	suite.Init(param, false)
+	return suite
+}
+
+// NewBlakeSHA512Ed448WithRand returns a cipher suite based on package
+// go.dedis.ch/kyber/v3/xof/blake2xb, SHA-512, and the edwards448 curve.
+// It produces cryptographically random numbers via the provided stream r.
+func NewBlakeSHA512Ed448WithRand(r cipher.Stream) *SuiteEd448 {
+	suite := NewBlakeSHA512Ed448()
+	suite.r = r
+	return suite
+}
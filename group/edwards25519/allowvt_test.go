@@ -3,6 +3,7 @@ package edwards25519
 import (
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v3"
 )
 
@@ -18,3 +19,36 @@ func TestVartime(t *testing.T) {
 		t.Fatal("expected Point to allow var time")
 	}
 }
+
+func TestMulVarTime(t *testing.T) {
+	p := tSuite.Point().Pick(tSuite.RandomStream())
+	for i := 0; i < 20; i++ {
+		s := tSuite.Scalar().Pick(tSuite.RandomStream())
+		if i == 0 {
+			s.Zero()
+		}
+		vt := tSuite.Point().(kyber.VarTimeMultiplier)
+		require.True(t, vt.MulVarTime(s, p).Equal(tSuite.Point().Mul(s, p)))
+		require.True(t, vt.MulVarTime(s, nil).Equal(tSuite.Point().Mul(s, nil)))
+	}
+}
+
+func BenchmarkPointMulConstantTime(b *testing.B) {
+	p := tSuite.Point().Pick(tSuite.RandomStream())
+	s := tSuite.Scalar().Pick(tSuite.RandomStream())
+	r := tSuite.Point()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.Mul(s, p)
+	}
+}
+
+func BenchmarkPointMulVarTime(b *testing.B) {
+	p := tSuite.Point().Pick(tSuite.RandomStream())
+	s := tSuite.Scalar().Pick(tSuite.RandomStream())
+	r := tSuite.Point().(kyber.VarTimeMultiplier)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		r.MulVarTime(s, p)
+	}
+}
@@ -3,6 +3,7 @@ package edwards25519
 import (
 	"crypto/cipher"
 	"crypto/sha512"
+	"math/big"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/util/random"
@@ -45,6 +46,18 @@ func (c *Curve) Point() kyber.Point {
 	return P
 }
 
+// Order returns the prime order of the subgroup generated by the base point.
+// It implements the kyber.GroupParameters interface.
+func (c *Curve) Order() *big.Int {
+	return primeOrder
+}
+
+// Cofactor returns 8, the cofactor of the Ed25519 curve. It implements the
+// kyber.GroupParameters interface.
+func (c *Curve) Cofactor() *big.Int {
+	return cofactor
+}
+
 // NewKeyAndSeedWithInput returns a formatted Ed25519 key (avoid subgroup attack by
 // requiring it to be a multiple of 8). It also returns the input and the digest used
 // to generate the key.
@@ -74,3 +87,27 @@ func (c *Curve) NewKey(stream cipher.Stream) kyber.Scalar {
 	secret, _, _ := c.NewKeyAndSeed(stream)
 	return secret
 }
+
+// RandomPoint returns a random point of the prime-order subgroup picked from
+// rand. It implements the kyber.PointGenerator interface.
+func (c *Curve) RandomPoint(rand cipher.Stream) kyber.Point {
+	return c.Point().Pick(rand)
+}
+
+// HashToPoint deterministically maps data to a point of the prime-order
+// subgroup with the Elligator 2 map: both halves of the SHA-512 digest of
+// data are mapped with Elligator2Decode, which clears the cofactor, and the
+// two points are added so that the result is close to uniform, as in the
+// random oracle encodings of RFC 9380. The hash is not the one of the
+// RFC, so the points differ from its edwards25519_XMD:SHA-512_ELL2_RO_
+// suite. It implements the kyber.PointGenerator interface.
+func (c *Curve) HashToPoint(data []byte) kyber.Point {
+	h := sha512.New()
+	_, _ = h.Write([]byte("Ed25519 hash to point"))
+	_, _ = h.Write(data)
+	digest := h.Sum(nil)
+	// Elligator2Decode only fails on inputs that are not 32 bytes long
+	P, _ := Elligator2Decode(digest[:32])
+	Q, _ := Elligator2Decode(digest[32:])
+	return P.Add(P, Q)
+}
@@ -1,10 +1,13 @@
 package edwards25519
 
 import (
+	"crypto/sha512"
 	"math"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/util/test"
 )
 
@@ -44,3 +47,46 @@ func BenchmarkPointBaseMul(b *testing.B) { groupBench.PointBaseMul(b.N) }
 func BenchmarkPointPick(b *testing.B)    { groupBench.PointPick(b.N) }
 func BenchmarkPointEncode(b *testing.B)  { groupBench.PointEncode(b.N) }
 func BenchmarkPointDecode(b *testing.B)  { groupBench.PointDecode(b.N) }
+
+func TestCurve_HashToPoint(t *testing.T) {
+	var _ kyber.PointGenerator = tSuite
+	null := tSuite.Point().Null()
+	for i := 0; i < 100; i++ {
+		data := []byte{byte(i), 'h', 'a', 's', 'h'}
+		p := tSuite.HashToPoint(data)
+		assert.True(t, p.Equal(tSuite.HashToPoint(data)))
+		assert.False(t, p.Equal(null))
+		assert.False(t, p.Equal(tSuite.Point().Base()))
+
+		// on the curve and in the prime-order subgroup
+		buff, err := p.MarshalBinary()
+		assert.NoError(t, err)
+		q := tSuite.Point()
+		assert.NoError(t, q.UnmarshalBinary(buff))
+		assert.True(t, q.Mul(primeOrderScalar, q).Equal(null))
+
+		r := tSuite.RandomPoint(tSuite.RandomStream())
+		assert.True(t, r.Mul(primeOrderScalar, r).Equal(null))
+	}
+	assert.False(t, tSuite.HashToPoint([]byte("a")).Equal(tSuite.HashToPoint([]byte("b"))))
+
+	// the sum of the Elligator 2 maps of both halves of the digest
+	digest := sha512.Sum512([]byte("Ed25519 hash to pointdata"))
+	P, err := Elligator2Decode(digest[:32])
+	assert.NoError(t, err)
+	Q, err := Elligator2Decode(digest[32:])
+	assert.NoError(t, err)
+	assert.True(t, tSuite.HashToPoint([]byte("data")).Equal(P.Add(P, Q)))
+}
+
+func TestCurveGroupParameters(t *testing.T) {
+	var params kyber.GroupParameters = &Curve{}
+	// 2^252 + 27742317777372353535851937790883648493
+	l, _ := new(big.Int).SetString("1000000000000000000000000000000014def9dea2f79cd65812631a5cf5d3ed", 16)
+	assert.Equal(t, 0, params.Order().Cmp(l))
+	assert.Equal(t, int64(8), params.Cofactor().Int64())
+
+	// the base point has the prime order
+	o := new(point).Mul(newScalarInt(l), nil)
+	assert.True(t, o.Equal(new(point).Null()))
+}
@@ -0,0 +1,194 @@
+package edwards25519
+
+import (
+	"crypto/cipher"
+	"errors"
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// sqrtMinusAPlus2 is sqrt(-(A+2)) = sqrt(-486664), the factor of the
+// birational map between Curve25519 and edwards25519:
+// (x, y) = (sqrt(-486664)*u/v, (u-1)/(u+1)).
+var sqrtMinusAPlus2 fieldElement
+
+// lowOrderGenerator is a point of order 8, which generates the points of
+// small order.
+var lowOrderGenerator point
+
+// clearLowOrderScalar is 8*(1/8 mod l), which is 1 modulo l and 0 modulo 8:
+// multiplying by it removes the component of small order of a point and
+// keeps the one of prime order.
+var clearLowOrderScalar = newScalarInt(new(big.Int).Mul(cofactor, new(big.Int).ModInverse(cofactor, primeOrder)))
+
+func init() {
+	if err := lowOrderGenerator.UnmarshalBinary(weakKeys[2]); err != nil {
+		panic(err)
+	}
+
+	v := new(big.Int).Sub(prime, big.NewInt(486664))
+	v.ModSqrt(v, prime)
+	var b [32]byte
+	be := v.Bytes()
+	for i := range be {
+		b[i] = be[len(be)-1-i]
+	}
+	feFromBytes(&sqrtMinusAPlus2, b[:])
+}
+
+// Elligator2Encode returns a 32-byte representative of P that is
+// indistinguishable from uniform random bytes, using the inverse of the
+// Elligator 2 map of Bernstein, Hamburg, Krasnova and Lange, "Elligator:
+// Elliptic-curve points indistinguishable from uniform random strings".
+// Only about half of the points have a representative: the boolean is false
+// for the others, and callers are expected to retry with a fresh key.
+//
+// Points of the prime-order subgroup, as public keys, would be recognizable
+// once decoded, so a random point of small order is added to P before
+// encoding, and Elligator2Decode removes it. Its choice and the two most
+// significant bits, which the map does not use, are read from rand.
+func Elligator2Encode(P kyber.Point, rand cipher.Stream) ([]byte, bool) {
+	var k [1]byte
+	rand.XORKeyStream(k[:], k[:])
+	var Q point
+	Q.Mul(newScalarInt(big.NewInt(int64(k[0]&7))), &lowOrderGenerator)
+	Q.Add(&Q, P)
+	ge := &Q.ge
+	var one, two, zInv, x, y, u, v, uA, num, den, r2, r, t fieldElement
+	feOne(&one)
+	feAdd(&two, &one, &one)
+	feInvert(&zInv, &ge.Z)
+	feMul(&x, &ge.X, &zInv)
+	feMul(&y, &ge.Y, &zInv)
+
+	// u = (1+y)/(1-y), v = sqrt(-(A+2))*u/x. The identity has no
+	// Montgomery form, and (0,-1) maps to (0,0) since 1/0 is 0 here.
+	feSub(&den, &one, &y)
+	ok := feIsNonZero(&den)
+	feInvert(&den, &den)
+	feAdd(&u, &one, &y)
+	feMul(&u, &u, &den)
+	feInvert(&t, &x)
+	feMul(&v, &u, &t)
+	feMul(&v, &v, &sqrtMinusAPlus2)
+
+	// r^2 = -u/(2(u+A)) if v is non-negative, -(u+A)/(2u) otherwise
+	feAdd(&uA, &u, &paramA)
+	ok &= feIsNonZero(&uA)
+	negative := int32(feIsNegative(&v))
+	feCopy(&num, &u)
+	feCopy(&den, &uA)
+	feCMove(&num, &uA, negative)
+	feCMove(&den, &u, negative)
+	feMul(&den, &den, &two) // feAdd would exceed the limb bounds of feMul
+	feInvert(&den, &den)
+	feMul(&r2, &num, &den)
+	feNeg(&r2, &r2)
+	ok &= feSqrt(&r, &r2)
+
+	// keep the root in [0, (p-1)/2], the one whose double is below p
+	feAdd(&t, &r, &r)
+	feNeg(&num, &r)
+	feCMove(&r, &num, int32(feIsNegative(&t)))
+	if ok != 1 {
+		return nil, false
+	}
+
+	var b [32]byte
+	feToBytes(&b, &r)
+	var pad [1]byte
+	rand.XORKeyStream(pad[:], pad[:])
+	b[31] |= pad[0] & 0xc0
+	return b[:], true
+}
+
+// Elligator2Decode maps any 32-byte string to a point of the curve with the
+// Elligator 2 map, ignoring the two most significant bits, and clears its
+// component of small order. The point is thus in the prime-order subgroup,
+// and Elligator2Decode is the inverse of Elligator2Encode for its points.
+func Elligator2Decode(b []byte) (kyber.Point, error) {
+	P, err := elligator2Map(b)
+	if err != nil {
+		return nil, err
+	}
+	return P.Mul(clearLowOrderScalar, P), nil
+}
+
+// elligator2Map returns the point of the curve that b maps to.
+func elligator2Map(b []byte) (*point, error) {
+	if len(b) != 32 {
+		return nil, errors.New("invalid Elligator2 representative length")
+	}
+	var buf [32]byte
+	copy(buf[:], b)
+	buf[31] &= 0x3f
+
+	var one, r, w, t, gw, u, v, negV, x, y fieldElement
+	feOne(&one)
+	feFromBytes(&r, buf[:])
+
+	// w = -A/(1+2r^2)
+	feSquare(&t, &r)
+	feAdd(&t, &t, &t)
+	feAdd(&t, &t, &one)
+	feInvert(&t, &t)
+	feMul(&w, &paramA, &t)
+	feNeg(&w, &w)
+
+	// u = w if w^3+Aw^2+w is a square and -w-A otherwise, v is negative in
+	// the first case and non-negative in the second
+	montgomeryRHS(&gw, &w)
+	isSquare := feSqrt(&t, &gw)
+	feNeg(&u, &w)
+	feSub(&u, &u, &paramA)
+	feCMove(&u, &w, isSquare)
+	montgomeryRHS(&t, &u)
+	feSqrt(&v, &t)
+	feNeg(&negV, &v)
+	feCMove(&v, &negV, int32(feIsNegative(&v))^isSquare)
+
+	// x = sqrt(-(A+2))*u/v, y = (u-1)/(u+1)
+	feInvert(&t, &v)
+	feMul(&x, &u, &t)
+	feMul(&x, &x, &sqrtMinusAPlus2)
+	feAdd(&t, &u, &one)
+	feInvert(&t, &t)
+	feSub(&y, &u, &one)
+	feMul(&y, &y, &t)
+
+	P := new(point)
+	P.ge.X = x
+	P.ge.Y = y
+	feOne(&P.ge.Z)
+	feMul(&P.ge.T, &x, &y)
+	return P, nil
+}
+
+// montgomeryRHS sets out to u^3 + A*u^2 + u, the right-hand side of the
+// Curve25519 equation.
+func montgomeryRHS(out, u *fieldElement) {
+	var t, one fieldElement
+	feOne(&one)
+	feAdd(&t, u, &paramA)
+	feMul(&t, &t, u)
+	feAdd(&t, &t, &one)
+	feMul(out, &t, u)
+}
+
+// feSqrt sets out to a square root of a and returns 1 if a is a square, or
+// returns 0 and leaves out with an unspecified value otherwise.
+func feSqrt(out, a *fieldElement) int32 {
+	var t, check, t2 fieldElement
+	fePow22523(&t, a)
+	feMul(&t, &t, a) // a^((p+3)/8)
+	feSquare(&check, &t)
+	feSub(&t2, &check, a)
+	isRoot := 1 ^ feIsNonZero(&t2)
+	feAdd(&t2, &check, a)
+	isNegRoot := 1 ^ feIsNonZero(&t2)
+	feMul(&t2, &t, &sqrtM1)
+	feCMove(&t, &t2, isNegRoot&(1^isRoot))
+	feCopy(out, &t)
+	return isRoot | isNegRoot
+}
@@ -0,0 +1,76 @@
+package edwards25519
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestElligator2RoundTrip(t *testing.T) {
+	stream := random.New()
+	encodable := 0
+	for i := 0; i < 200; i++ {
+		P := tSuite.Point().Pick(stream)
+		b, ok := Elligator2Encode(P, stream)
+		if !ok {
+			continue
+		}
+		encodable++
+		require.Len(t, b, 32)
+		Q, err := Elligator2Decode(b)
+		require.NoError(t, err)
+		require.True(t, P.Equal(Q))
+
+		// the padding bits are ignored
+		b[31] ^= 0xc0
+		Q, err = Elligator2Decode(b)
+		require.NoError(t, err)
+		require.True(t, P.Equal(Q))
+	}
+	// about half of the points are encodable
+	require.True(t, encodable > 60 && encodable < 140, "%d encodable points", encodable)
+
+	_, err := Elligator2Decode(make([]byte, 31))
+	require.Error(t, err)
+}
+
+func TestElligator2Decode(t *testing.T) {
+	stream := random.New()
+	for i := 0; i < 100; i++ {
+		b := random.Bits(256, false, stream)
+		P, err := Elligator2Decode(b)
+		require.NoError(t, err)
+
+		// the decoded point is on the curve
+		buf, err := P.MarshalBinary()
+		require.NoError(t, err)
+		Q := tSuite.Point()
+		require.NoError(t, Q.UnmarshalBinary(buf))
+		require.True(t, P.Equal(Q))
+
+		// in the prime-order subgroup
+		require.True(t, Q.Mul(primeOrderScalar, P).Equal(nullPoint))
+	}
+}
+
+func TestElligator2LowOrder(t *testing.T) {
+	// the representatives of points of prime order also map to points
+	// outside of the prime-order subgroup, which Elligator2Decode clears
+	stream := random.New()
+	inSubgroup := make(map[bool]bool)
+	for len(inSubgroup) < 2 {
+		P := tSuite.Point().Pick(stream)
+		b, ok := Elligator2Encode(P, stream)
+		if !ok {
+			continue
+		}
+		R, err := elligator2Map(b)
+		require.NoError(t, err)
+		inSubgroup[tSuite.Point().Mul(primeOrderScalar, R).Equal(nullPoint)] = true
+
+		Q, err := Elligator2Decode(b)
+		require.NoError(t, err)
+		require.True(t, P.Equal(Q))
+	}
+}
@@ -16,6 +16,7 @@ package edwards25519
 
 import (
 	"crypto/cipher"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -67,18 +68,23 @@ func (P *point) UnmarshalFrom(r io.Reader) (int, error) {
 	return marshalling.PointUnmarshalFrom(P, r)
 }
 
-// Equality test for two Points on the same curve
+// Equality test for two Points on the same curve. Both points are first
+// converted to their canonical encoding, so that different projective
+// representations of the same point are equal, and the encodings are
+// compared in constant time.
 func (P *point) Equal(P2 kyber.Point) bool {
 
 	var b1, b2 [32]byte
 	P.ge.ToBytes(&b1)
 	P2.(*point).ge.ToBytes(&b2)
-	for i := range b1 {
-		if b1[i] != b2[i] {
-			return false
-		}
-	}
-	return true
+	return subtle.ConstantTimeCompare(b1[:], b2[:]) == 1
+}
+
+// IsIdentity returns true if P is the neutral element, in constant time.
+func (P *point) IsIdentity() bool {
+	var null point
+	null.ge.Zero()
+	return P.Equal(&null)
 }
 
 // Set point to be equal to P2.
@@ -62,3 +62,62 @@ func Test_PointIsCanonical(t *testing.T) {
 	}
 	require.Equal(t, expectedNonCanonicalCount, actualNonCanonicalCount, "Incorrect number of non canonical points detected")
 }
+
+// TestPoint_EqualProjective checks that two different extended coordinates
+// representations of the same point are equal.
+func TestPoint_EqualProjective(t *testing.T) {
+	s := new(scalar).SetInt64(42)
+	p := new(point).Mul(s, nil).(*point)
+
+	// (X:Y:Z:T) and (lX:lY:lZ:lT) represent the same point
+	var l fieldElement
+	var b [32]byte
+	b[0] = 7
+	feFromBytes(&l, b[:])
+	q := new(point)
+	feMul(&q.ge.X, &p.ge.X, &l)
+	feMul(&q.ge.Y, &p.ge.Y, &l)
+	feMul(&q.ge.Z, &p.ge.Z, &l)
+	feMul(&q.ge.T, &p.ge.T, &l)
+	require.NotEqual(t, p.ge, q.ge)
+	require.True(t, p.Equal(q))
+	require.True(t, q.Equal(p))
+
+	r := new(point).Add(p, new(point).Base())
+	require.False(t, p.Equal(r))
+}
+
+func TestPoint_IsIdentity(t *testing.T) {
+	require.True(t, new(point).Null().(*point).IsIdentity())
+	require.False(t, new(point).Base().(*point).IsIdentity())
+
+	// the identity in another projective representation
+	p := new(point).Null().(*point)
+	var l fieldElement
+	var b [32]byte
+	b[0] = 7
+	feFromBytes(&l, b[:])
+	feMul(&p.ge.Y, &p.ge.Y, &l)
+	feMul(&p.ge.Z, &p.ge.Z, &l)
+	require.True(t, p.IsIdentity())
+
+	q := new(point).Base()
+	q.Sub(q, q)
+	require.True(t, q.(*point).IsIdentity())
+}
+
+func BenchmarkPointEqual(b *testing.B) {
+	p := new(point).Mul(new(scalar).SetInt64(42), nil)
+	q := p.Clone()
+	r := new(point).Base()
+	b.Run("equal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.Equal(q)
+		}
+	})
+	b.Run("different", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.Equal(r)
+		}
+	})
+}
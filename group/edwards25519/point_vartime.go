@@ -1,5 +1,7 @@
 package edwards25519
 
+import "go.dedis.ch/kyber/v3"
+
 // AllowVarTime sets a flag in this object which determines if a faster
 // but variable time implementation can be used. Set this only on Points
 // which represent public information. Using variable time algorithms to
@@ -7,3 +9,19 @@ package edwards25519
 func (P *point) AllowVarTime(varTime bool) {
 	P.varTime = varTime
 }
+
+// MulVarTime multiplies A by s, or the base point if A is nil, like Mul but
+// always with the variable time sliding window NAF method, whatever the
+// AllowVarTime flag is. WARNING: the running time depends on s, so it must
+// only be used with public scalars, never with secret ones. The
+// multiplication of the base point already uses a table of precomputed
+// multiples, which is faster, and is the same as in Mul.
+func (P *point) MulVarTime(s kyber.Scalar, A kyber.Point) kyber.Point {
+	a := &s.(*scalar).v
+	if A == nil {
+		geScalarMultBase(&P.ge, a)
+	} else {
+		geScalarMultVartime(&P.ge, a, &A.(*point).ge)
+	}
+	return P
+}
@@ -36,6 +36,12 @@ func (s *scalar) Equal(s2 kyber.Scalar) bool {
 	return subtle.ConstantTimeCompare(v1, v2) != 0
 }
 
+// IsZero returns true if the scalar is zero, in constant time.
+func (s *scalar) IsZero() bool {
+	var zero [32]byte
+	return subtle.ConstantTimeCompare(s.v[:], zero[:]) == 1
+}
+
 // Set equal to another Scalar a
 func (s *scalar) Set(a kyber.Scalar) kyber.Scalar {
 	s.v = a.(*scalar).v
@@ -132,6 +138,21 @@ func (s *scalar) Inv(a kyber.Scalar) kyber.Scalar {
 	return s
 }
 
+// InverseBlinded sets s to the modular inverse of a, like Inv, but inverts
+// a*b for a non-zero blinding factor b picked from rand and multiplies the
+// result by b, so that the inversion never operates on a itself.
+func (s *scalar) InverseBlinded(a kyber.Scalar, rand cipher.Stream) kyber.Scalar {
+	var b, zero scalar
+	for b.Equal(&zero) {
+		b.Pick(rand)
+	}
+	var ab scalar
+	scMul(&ab.v, &a.(*scalar).v, &b.v)
+	s.Inv(&ab)
+	scMul(&s.v, &s.v, &b.v)
+	return s
+}
+
 // Set to a fresh random or pseudo-random scalar
 func (s *scalar) Pick(rand cipher.Stream) kyber.Scalar {
 	i := mod.NewInt(random.Int(primeOrder, rand), primeOrder)
@@ -162,16 +183,29 @@ func (s *scalar) MarshalBinary() ([]byte, error) {
 	return s.toInt().MarshalBinary()
 }
 
+// Bytes returns the 32-byte little-endian encoding of this scalar. The slice
+// is freshly allocated on each call, so modifying it does not change the
+// scalar.
+func (s *scalar) Bytes() []byte {
+	b, _ := s.MarshalBinary()
+	return b
+}
+
 // MarshalID returns the type tag used in encoding/decoding
 func (s *scalar) MarshalID() [8]byte {
 	return marshalScalarID
 }
 
 // UnmarshalBinary reads the binary representation of a scalar.
+// Encodings of values greater than or equal to the group order are rejected,
+// since accepting them would make signatures malleable.
 func (s *scalar) UnmarshalBinary(buf []byte) error {
 	if len(buf) != 32 {
 		return errors.New("wrong size buffer")
 	}
+	if !s.IsCanonical(buf) {
+		return errors.New("scalar is not canonical")
+	}
 	copy(s.v[:], buf)
 	return nil
 }
@@ -0,0 +1,52 @@
+//go:build go1.18
+// +build go1.18
+
+package edwards25519
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// FuzzScalarUnmarshalBinary checks that exactly the canonical encodings,
+// those of values below the group order, are accepted, and that they
+// marshal back to the same bytes.
+func FuzzScalarUnmarshalBinary(f *testing.F) {
+	f.Add(make([]byte, 32))
+	f.Add(bytes.Repeat([]byte{0xff}, 32))
+	f.Add(reverseBytes(primeOrder.Bytes()))
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		s := new(scalar)
+		err := s.UnmarshalBinary(buf)
+		if len(buf) != 32 {
+			if err == nil {
+				t.Fatalf("accepted a %d-byte encoding", len(buf))
+			}
+			return
+		}
+		v := new(big.Int).SetBytes(reverseBytes(buf))
+		canonical := v.Cmp(primeOrder) < 0
+		if canonical != (err == nil) {
+			t.Fatalf("value %x: canonical %v, error %v", v, canonical, err)
+		}
+		if err != nil {
+			return
+		}
+		out, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf, out) {
+			t.Fatalf("round trip of %x gave %x", buf, out)
+		}
+	})
+}
+
+func reverseBytes(b []byte) []byte {
+	r := make([]byte, len(b))
+	for i := range b {
+		r[i] = b[len(b)-1-i]
+	}
+	return r
+}
@@ -479,3 +479,79 @@ func Test_ScalarIsCanonical(t *testing.T) {
 		candidateBuf[0]++
 	}
 }
+
+func TestScalarBytesCopy(t *testing.T) {
+	s := new(scalar).SetInt64(42)
+	b := s.(*scalar).Bytes()
+	expected, err := s.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, expected, b)
+
+	for i := range b {
+		b[i] = 0xff
+	}
+	require.True(t, s.Equal(new(scalar).SetInt64(42)))
+	require.Equal(t, expected, s.(*scalar).Bytes())
+	sum := new(scalar).Add(s, new(scalar).One())
+	require.True(t, sum.Equal(new(scalar).SetInt64(43)))
+}
+
+func TestScalarInverseBlinded(t *testing.T) {
+	stream := tSuite.RandomStream()
+	for i := 0; i < 20; i++ {
+		a := tSuite.Scalar().Pick(stream)
+		direct := tSuite.Scalar().Inv(a)
+		blinded := tSuite.Scalar().(*scalar).InverseBlinded(a, stream)
+		require.True(t, direct.Equal(blinded))
+
+		// the receiver may alias the argument
+		a.(*scalar).InverseBlinded(a, stream)
+		require.True(t, direct.Equal(a))
+	}
+}
+
+func BenchmarkScalarInverseBlinded(b *testing.B) {
+	stream := tSuite.RandomStream()
+	a := tSuite.Scalar().Pick(stream)
+	s := tSuite.Scalar().(*scalar)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.InverseBlinded(a, stream)
+	}
+}
+
+func TestScalarIsZero(t *testing.T) {
+	require.True(t, tSuite.Scalar().(*scalar).IsZero())
+	require.True(t, tSuite.Scalar().Zero().(*scalar).IsZero())
+	require.False(t, tSuite.Scalar().One().(*scalar).IsZero())
+	require.False(t, tSuite.Scalar().Pick(tSuite.RandomStream()).(*scalar).IsZero())
+	// L reduces to zero
+	s := tSuite.Scalar().Add(minusOne, tSuite.Scalar().One())
+	require.True(t, s.(*scalar).IsZero())
+}
+
+func TestScalarUnmarshalNonCanonical(t *testing.T) {
+	le := func(v *big.Int) []byte {
+		b := make([]byte, 32)
+		be := v.Bytes()
+		for i := range be {
+			b[i] = be[len(be)-1-i]
+		}
+		return b
+	}
+	max := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	for _, v := range []*big.Int{
+		primeOrder,
+		new(big.Int).Add(primeOrder, big.NewInt(1)),
+		new(big.Int).Lsh(big.NewInt(1), 253),
+		max,
+	} {
+		require.Error(t, new(scalar).UnmarshalBinary(le(v)), "%x", v)
+	}
+
+	lMinusOne := new(big.Int).Sub(primeOrder, big.NewInt(1))
+	s := new(scalar)
+	require.NoError(t, s.UnmarshalBinary(le(lMinusOne)))
+	require.True(t, s.Equal(minusOne))
+	require.NoError(t, s.UnmarshalBinary(make([]byte, 32)))
+}
@@ -0,0 +1,18 @@
+// Package group holds helpers common to all the groups of kyber, which are
+// implemented in its subpackages.
+package group
+
+import (
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+// NofGenerators returns n "nothing-up-my-sleeve" generators of g: each one
+// is the hash to a point of a domain specific to g and of its index, so that
+// no discrete logarithm relation between them or with the base point is
+// known. It returns the same generators for the same group and n, and an
+// error if g can not hash to a point. See share.PedersenGenerators to derive
+// generators specific to a protocol.
+func NofGenerators(g kyber.Group, n int) ([]kyber.Point, error) {
+	return share.PedersenGenerators(g, []byte("kyber generators "+g.String()), n)
+}
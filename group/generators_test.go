@@ -0,0 +1,27 @@
+package group
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+)
+
+func TestNofGenerators(t *testing.T) {
+	for _, g := range []kyber.Group{edwards25519.NewBlakeSHA256Ed25519(), bn256.NewSuite().G2()} {
+		gens, err := NofGenerators(g, 8)
+		require.NoError(t, err)
+		require.Len(t, gens, 8)
+		again, err := NofGenerators(g, 8)
+		require.NoError(t, err)
+		for i, h := range gens {
+			require.True(t, h.Equal(again[i]))
+			require.False(t, h.Equal(g.Point().Base()))
+			for _, h2 := range gens[:i] {
+				require.False(t, h.Equal(h2))
+			}
+		}
+	}
+}
@@ -4,6 +4,7 @@ package mod
 
 import (
 	"crypto/cipher"
+	"crypto/subtle"
 	"encoding/hex"
 	"errors"
 	"io"
@@ -149,6 +150,18 @@ func (i *Int) Nonzero() bool {
 	return i.V.Sign() != 0
 }
 
+// IsZero returns true if the integer is zero. Unlike Nonzero, it inspects
+// the fixed-length encoding of the value so that the time it takes does not
+// depend on which non-zero value it holds.
+func (i *Int) IsZero() bool {
+	buf, _ := i.MarshalBinary()
+	var acc byte
+	for _, b := range buf {
+		acc |= b
+	}
+	return subtle.ConstantTimeByteEq(acc, 0) == 1
+}
+
 // Set both value and modulus to be equal to another Int.
 // Since this method copies the modulus as well,
 // it may be used as an alternative to Init().
@@ -264,6 +277,21 @@ func (i *Int) Inv(a kyber.Scalar) kyber.Scalar {
 	return i
 }
 
+// InverseBlinded sets the target to the modular inverse of a, like Inv, but
+// inverts a*b for a non-zero blinding factor b picked from rand and
+// multiplies the result by b. The big.Int inversion used by Inv does not run
+// in constant time, blinding keeps its timing independent of a.
+func (i *Int) InverseBlinded(a kyber.Scalar, rand cipher.Stream) kyber.Scalar {
+	ai := a.(*Int)
+	b := NewInt64(0, ai.M)
+	for !b.Nonzero() {
+		b.Pick(rand)
+	}
+	ab := NewInt64(0, ai.M).Mul(ai, b)
+	i.Inv(ab)
+	return i.Mul(i, b)
+}
+
 // Exp sets the target to a^e mod M,
 // where e is an arbitrary big.Int exponent (not necessarily 0 <= e < M).
 func (i *Int) Exp(a kyber.Scalar, e *big.Int) kyber.Scalar {
@@ -329,6 +357,13 @@ func (i *Int) MarshalBinary() ([]byte, error) {
 	return b, nil
 }
 
+// Bytes returns the encoding of this Int as MarshalBinary does. The slice is
+// freshly allocated on each call, so modifying it does not change the Int.
+func (i *Int) Bytes() []byte {
+	b, _ := i.MarshalBinary()
+	return b
+}
+
 // MarshalID returns a unique identifier for this type
 func (i *Int) MarshalID() [8]byte {
 	return marshalScalarID
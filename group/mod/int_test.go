@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/util/random"
 )
 
 func TestIntEndianness(t *testing.T) {
@@ -98,3 +99,58 @@ func TestIntClone(t *testing.T) {
 		t.Error("Should not be equal")
 	}
 }
+
+func TestIntBytesCopy(t *testing.T) {
+	modulo := big.NewInt(65535)
+	for _, bo := range []ByteOrder{BigEndian, LittleEndian} {
+		// values of full length and shorter than the modulus
+		for _, v := range []int64{65500, 3} {
+			i := new(Int).Init64(v, modulo)
+			i.BO = bo
+			b := i.Bytes()
+			for j := range b {
+				b[j] ^= 0xff
+			}
+			require.Equal(t, v, i.V.Int64())
+			require.True(t, i.Equal(new(Int).Init64(v, modulo)))
+			require.NotEqual(t, b, i.Bytes())
+		}
+	}
+}
+
+func TestIntInverseBlinded(t *testing.T) {
+	modulo, ok := new(big.Int).SetString("fffffffffffffffffffffffffffffffeffffffffffffffff", 16)
+	require.True(t, ok)
+	stream := random.New()
+	for i := 0; i < 20; i++ {
+		a := NewInt64(0, modulo)
+		a.Pick(stream)
+		direct := NewInt64(0, modulo).Inv(a)
+		blinded := NewInt64(0, modulo).InverseBlinded(a, stream)
+		require.True(t, direct.Equal(blinded))
+
+		// the receiver may alias the argument
+		a.InverseBlinded(a, stream)
+		require.True(t, direct.Equal(a))
+	}
+}
+
+func BenchmarkIntInverseBlinded(b *testing.B) {
+	modulo, _ := new(big.Int).SetString("fffffffffffffffffffffffffffffffeffffffffffffffff", 16)
+	stream := random.New()
+	a := NewInt64(0, modulo)
+	a.Pick(stream)
+	s := NewInt64(0, modulo)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.InverseBlinded(a, stream)
+	}
+}
+
+func TestIntIsZero(t *testing.T) {
+	modulo := big.NewInt(65535)
+	require.True(t, NewInt64(0, modulo).IsZero())
+	require.True(t, NewInt64(65535, modulo).IsZero())
+	require.False(t, NewInt64(1, modulo).IsZero())
+	require.False(t, NewInt64(256, modulo).IsZero())
+}
@@ -3,6 +3,7 @@ package nist
 import (
 	"crypto/cipher"
 	"crypto/elliptic"
+	"crypto/subtle"
 	"errors"
 	"io"
 	"math/big"
@@ -11,6 +12,7 @@ import (
 	"go.dedis.ch/kyber/v3/group/internal/marshalling"
 	"go.dedis.ch/kyber/v3/group/mod"
 	"go.dedis.ch/kyber/v3/util/random"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
 )
 
 type curvePoint struct {
@@ -22,18 +24,37 @@ func (p *curvePoint) String() string {
 	return "(" + p.x.String() + "," + p.y.String() + ")"
 }
 
+// Equal compares the normalized coordinates of both points. The coordinates
+// are encoded with a fixed length and compared in full, without stopping at
+// the first difference, and neither point is modified.
 func (p *curvePoint) Equal(p2 kyber.Point) bool {
 	cp2 := p2.(*curvePoint)
+	return subtle.ConstantTimeCompare(p.coords(), cp2.coords()) == 1
+}
 
-	// Make sure both coordinates are normalized.
-	// Apparently Go's elliptic curve code doesn't always ensure this.
-	M := p.c.p.P
-	p.x.Mod(p.x, M)
-	p.y.Mod(p.y, M)
-	cp2.x.Mod(cp2.x, M)
-	cp2.y.Mod(cp2.y, M)
+// IsIdentity returns true if p is the point at infinity, which this package
+// represents with the coordinates (0, 0), in constant time.
+func (p *curvePoint) IsIdentity() bool {
+	var acc byte
+	for _, b := range p.coords() {
+		acc |= b
+	}
+	return subtle.ConstantTimeByteEq(acc, 0) == 1
+}
 
-	return p.x.Cmp(cp2.x) == 0 && p.y.Cmp(cp2.y) == 0
+// coords returns the fixed-length big-endian encoding of the coordinates
+// reduced modulo the field prime.
+// Apparently Go's elliptic curve code doesn't always ensure they are
+// normalized.
+func (p *curvePoint) coords() []byte {
+	M := p.c.p.P
+	l := p.c.coordLen()
+	b := make([]byte, 2*l)
+	x := new(big.Int).Mod(p.x, M).Bytes()
+	y := new(big.Int).Mod(p.y, M).Bytes()
+	copy(b[l-len(x):l], x)
+	copy(b[2*l-len(y):], y)
+	return b
 }
 
 func (p *curvePoint) Null() kyber.Point {
@@ -146,16 +167,27 @@ func (p *curvePoint) Sub(a, b kyber.Point) kyber.Point {
 	ca := a.(*curvePoint)
 	cb := b.(*curvePoint)
 
-	cbn := p.c.Point().Neg(cb).(*curvePoint)
-	p.x, p.y = p.c.Add(ca.x, ca.y, cbn.x, cbn.y)
+	x, y := cb.x, p.c.negY(cb.y)
+	p.x, p.y = p.c.Add(ca.x, ca.y, x, y)
 	return p
 }
 
+// Neg sets p to the negative of a. On a short Weierstrass curve the
+// negative of (x,y) is (x,-y), so no scalar multiplication is needed.
 func (p *curvePoint) Neg(a kyber.Point) kyber.Point {
+	ca := a.(*curvePoint)
+	p.x, p.y = new(big.Int).Set(ca.x), p.c.negY(ca.y)
+	return p
+}
 
-	s := p.c.Scalar().One()
-	s.Neg(s)
-	return p.Mul(s, a).(*curvePoint)
+// negY returns a new integer holding -y mod P. The point at infinity is
+// encoded as (0,0) and is its own negative.
+func (c *curve) negY(y *big.Int) *big.Int {
+	n := new(big.Int).Mod(y, c.p.P)
+	if n.Sign() != 0 {
+		n.Sub(c.p.P, n)
+	}
+	return n
 }
 
 func (p *curvePoint) Mul(s kyber.Scalar, b kyber.Point) kyber.Point {
@@ -250,6 +282,21 @@ func (c *curve) Point() kyber.Point {
 	return p
 }
 
+// RandomPoint returns a random point picked from rand. It implements the
+// kyber.PointGenerator interface.
+func (c *curve) RandomPoint(rand cipher.Stream) kyber.Point {
+	return c.Point().Pick(rand)
+}
+
+// HashToPoint deterministically maps data to a curve point with the
+// try-and-increment method: candidate x-coordinates are read from a Blake2xb
+// XOF seeded with data until one of them is on the curve. It implements the
+// kyber.PointGenerator interface.
+func (c *curve) HashToPoint(data []byte) kyber.Point {
+	seed := append([]byte(c.p.Name+" hash to point"), data...)
+	return c.Point().Pick(blake2xb.New(seed))
+}
+
 func (p *curvePoint) Set(P kyber.Point) kyber.Point {
 	p.x = P.(*curvePoint).x
 	p.y = P.(*curvePoint).y
@@ -264,3 +311,9 @@ func (p *curvePoint) Clone() kyber.Point {
 func (c *curve) Order() *big.Int {
 	return c.p.N
 }
+
+// Cofactor returns 1, the cofactor of the NIST prime-order curves. It
+// implements the kyber.GroupParameters interface together with Order.
+func (c *curve) Cofactor() *big.Int {
+	return one
+}
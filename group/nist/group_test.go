@@ -1,8 +1,11 @@
 package nist
 
 import (
+	"math/big"
 	"testing"
 
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
 	"go.dedis.ch/kyber/v3/util/test"
 )
 
@@ -14,6 +17,21 @@ var testP256 = NewBlakeSHA256P256()
 
 func TestP256(t *testing.T) { test.SuiteTest(t, testP256) }
 
+func TestGroupParameters(t *testing.T) {
+	var p256 kyber.GroupParameters = testP256
+	n, _ := new(big.Int).SetString("ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 16)
+	if p256.Order().Cmp(n) != 0 || p256.Cofactor().Cmp(big.NewInt(1)) != 0 {
+		t.Fatal("wrong P-256 parameters")
+	}
+
+	var qr kyber.GroupParameters = testQR512
+	pm1 := new(big.Int).Sub(testQR512.P, big.NewInt(1))
+	if qr.Cofactor().Cmp(big.NewInt(2)) != 0 ||
+		new(big.Int).Mul(qr.Order(), qr.Cofactor()).Cmp(pm1) != 0 {
+		t.Fatal("wrong QR512 parameters")
+	}
+}
+
 func TestSetBytesBE(t *testing.T) {
 	s := testP256.Scalar()
 	s.SetBytes([]byte{0, 1, 2, 3})
@@ -44,3 +62,103 @@ func BenchmarkPointBaseMul(b *testing.B) { benchP256.PointBaseMul(b.N) }
 func BenchmarkPointPick(b *testing.B)    { benchP256.PointPick(b.N) }
 func BenchmarkPointEncode(b *testing.B)  { benchP256.PointEncode(b.N) }
 func BenchmarkPointDecode(b *testing.B)  { benchP256.PointDecode(b.N) }
+
+// TestP256PointEqual checks that points with non-reduced coordinates are equal
+// to their normalized counterpart, and that Equal does not modify them.
+func TestP256PointEqual(t *testing.T) {
+	p := testP256.Point().Pick(testP256.RandomStream()).(*curvePoint)
+	M := p.c.p.P
+	q := &curvePoint{
+		x: new(big.Int).Add(p.x, M),
+		y: new(big.Int).Add(p.y, M),
+		c: p.c,
+	}
+	qx := new(big.Int).Set(q.x)
+	if !p.Equal(q) || !q.Equal(p) {
+		t.Fatal("non-reduced point is not equal to the reduced one")
+	}
+	if q.x.Cmp(qx) != 0 {
+		t.Fatal("Equal modified its argument")
+	}
+	if p.Equal(testP256.Point().Base()) {
+		t.Fatal("different points are equal")
+	}
+}
+
+func BenchmarkPointEqual(b *testing.B) {
+	p := testP256.Point().Pick(testP256.RandomStream())
+	q := p.Clone()
+	r := testP256.Point().Base()
+	b.Run("equal", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.Equal(q)
+		}
+	})
+	b.Run("different", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			p.Equal(r)
+		}
+	})
+}
+
+func TestP256HashToPoint(t *testing.T) {
+	var _ kyber.PointGenerator = testP256
+	for i := 0; i < 100; i++ {
+		data := []byte{byte(i), 'h', 'a', 's', 'h'}
+		p := testP256.HashToPoint(data).(*curvePoint)
+		if !p.Equal(testP256.HashToPoint(data)) {
+			t.Fatal("HashToPoint is not deterministic")
+		}
+		if !p.Valid() || p.Equal(testP256.Point().Null()) {
+			t.Fatal("HashToPoint returned an invalid point")
+		}
+		// P-256 has a prime order, so any point other than the identity is
+		// in the prime-order group.
+		q := testP256.Point().Mul(testP256.Scalar().SetInt64(-1), p)
+		if !q.Add(q, p).Equal(testP256.Point().Null()) {
+			t.Fatal("HashToPoint returned a point outside the group")
+		}
+		if !testP256.RandomPoint(testP256.RandomStream()).(*curvePoint).Valid() {
+			t.Fatal("RandomPoint returned an invalid point")
+		}
+	}
+	if testP256.HashToPoint([]byte("a")).Equal(testP256.HashToPoint([]byte("b"))) {
+		t.Fatal("different inputs map to the same point")
+	}
+}
+
+func TestP256IsIdentity(t *testing.T) {
+	p := testP256.Point().Pick(testP256.RandomStream())
+	if !testP256.Point().Null().(*curvePoint).IsIdentity() ||
+		!testP256.Point().Sub(p, p).(*curvePoint).IsIdentity() {
+		t.Fatal("identity not detected")
+	}
+	if testP256.Point().Base().(*curvePoint).IsIdentity() || p.(*curvePoint).IsIdentity() {
+		t.Fatal("non-identity point detected as identity")
+	}
+
+	if !testP256.Scalar().(*mod.Int).IsZero() || !testP256.Scalar().Zero().(*mod.Int).IsZero() {
+		t.Fatal("zero scalar not detected")
+	}
+	if testP256.Scalar().One().(*mod.Int).IsZero() ||
+		testP256.Scalar().Pick(testP256.RandomStream()).(*mod.Int).IsZero() {
+		t.Fatal("non-zero scalar detected as zero")
+	}
+}
+
+func TestP256ScalarUnmarshalNonCanonical(t *testing.T) {
+	// big-endian encoding of v on 32 bytes
+	encode := func(v *big.Int) []byte {
+		b := v.Bytes()
+		return append(make([]byte, 32-len(b)), b...)
+	}
+	order := testP256.p.N
+	for _, v := range []*big.Int{order, new(big.Int).Add(order, big.NewInt(1))} {
+		if testP256.Scalar().UnmarshalBinary(encode(v)) == nil {
+			t.Fatalf("non-canonical scalar %x accepted", v)
+		}
+	}
+	if err := testP256.Scalar().UnmarshalBinary(encode(new(big.Int).Sub(order, big.NewInt(1)))); err != nil {
+		t.Fatal(err)
+	}
+}
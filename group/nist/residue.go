@@ -227,6 +227,12 @@ func (g *ResidueGroup) Order() *big.Int {
 	return g.Q
 }
 
+// Cofactor returns R, the ratio between P-1 and the order of this Residue
+// group. It implements the kyber.GroupParameters interface.
+func (g *ResidueGroup) Cofactor() *big.Int {
+	return g.R
+}
+
 // Valid validates the parameters for a Residue group,
 // checking that P and Q are prime, P=Q*R+1,
 // and that G is a valid generator for this group.
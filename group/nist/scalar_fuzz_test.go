@@ -0,0 +1,45 @@
+//go:build go1.18
+// +build go1.18
+
+package nist
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+// FuzzP256ScalarUnmarshalBinary checks that exactly the canonical encodings,
+// those of values below the group order, are accepted, and that they
+// marshal back to the same bytes.
+func FuzzP256ScalarUnmarshalBinary(f *testing.F) {
+	order := testP256.p.N
+	f.Add(make([]byte, 32))
+	f.Add(bytes.Repeat([]byte{0xff}, 32))
+	f.Add(order.Bytes())
+	f.Fuzz(func(t *testing.T, buf []byte) {
+		s := testP256.Scalar()
+		err := s.UnmarshalBinary(buf)
+		if len(buf) != 32 {
+			if err == nil {
+				t.Fatalf("accepted a %d-byte encoding", len(buf))
+			}
+			return
+		}
+		v := new(big.Int).SetBytes(buf)
+		canonical := v.Cmp(order) < 0
+		if canonical != (err == nil) {
+			t.Fatalf("value %x: canonical %v, error %v", v, canonical, err)
+		}
+		if err != nil {
+			return
+		}
+		out, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(buf, out) {
+			t.Fatalf("round trip of %x gave %x", buf, out)
+		}
+	})
+}
@@ -0,0 +1,81 @@
+// Package secp256k1 implements the kyber.Group interface for the secp256k1
+// elliptic curve of SEC 2, used among others by Bitcoin and Ethereum.
+//
+// Field arithmetic and scalar multiplication run in constant time, while the
+// arithmetic on scalars themselves relies on mod.Int and does not. Points are
+// encoded in the compressed SEC1 format and can be decoded from both the
+// compressed and the uncompressed formats. Scalars are big-endian integers,
+// compatible with Go's big.Int and the usual secp256k1 libraries.
+package secp256k1
+
+import (
+	"crypto/cipher"
+	"math/big"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
+)
+
+// order is the prime order n of the group.
+var order, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+
+// Curve represents the secp256k1 group.
+// There are no parameters and no initialization is required
+// because it supports only this one specific curve.
+type Curve struct {
+}
+
+// String returns the name of the curve, "Secp256k1".
+func (c *Curve) String() string {
+	return "Secp256k1"
+}
+
+// ScalarLen returns 32, the size in bytes of an encoded Scalar.
+func (c *Curve) ScalarLen() int {
+	return 32
+}
+
+// Scalar creates a new Scalar modulo the order of the curve. The scalars
+// interpret the bytes given to SetBytes as a big-endian integer.
+func (c *Curve) Scalar() kyber.Scalar {
+	return mod.NewInt64(0, order)
+}
+
+// PointLen returns 33, the size in bytes of a compressed SEC1 point.
+func (c *Curve) PointLen() int {
+	return compressedLen
+}
+
+// Point creates a new Point, initialized to the point at infinity.
+func (c *Curve) Point() kyber.Point {
+	p := new(point)
+	p.Null()
+	return p
+}
+
+// Order returns the prime order of the group.
+func (c *Curve) Order() *big.Int {
+	return order
+}
+
+// Cofactor returns 1, since secp256k1 has a prime order. It implements the
+// kyber.GroupParameters interface.
+func (c *Curve) Cofactor() *big.Int {
+	return big.NewInt(1)
+}
+
+// RandomPoint returns a random point picked from rand. It implements the
+// kyber.PointGenerator interface.
+func (c *Curve) RandomPoint(rand cipher.Stream) kyber.Point {
+	return c.Point().Pick(rand)
+}
+
+// HashToPoint deterministically maps data to a curve point with the
+// try-and-increment method: candidate x-coordinates are read from a Blake2xb
+// XOF seeded with data until one of them is on the curve. It implements the
+// kyber.PointGenerator interface.
+func (c *Curve) HashToPoint(data []byte) kyber.Point {
+	seed := append([]byte("Secp256k1 hash to point"), data...)
+	return c.Point().Pick(blake2xb.New(seed))
+}
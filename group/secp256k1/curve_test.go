@@ -0,0 +1,220 @@
+package secp256k1
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/mod"
+	"go.dedis.ch/kyber/v3/util/test"
+)
+
+var tSuite = NewBlakeSHA256Secp256k1()
+
+func TestSecp256k1Group(t *testing.T) {
+	test.GroupTest(t, new(Curve))
+}
+
+func TestSecp256k1Suite(t *testing.T) {
+	test.SuiteTest(t, tSuite)
+}
+
+// Affine coordinates of k*G for small k, and of (n-1)*G = -G.
+var baseMultiples = []struct {
+	k    string
+	x, y string
+}{
+	{"1",
+		"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		"483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8"},
+	{"2",
+		"c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5",
+		"1ae168fea63dc339a3c58419466ceaeef7f632653266d0e1236431a950cfe52a"},
+	{"3",
+		"f9308a019258c31049344f85f89d5229b531c845836f99b08601f113bce036f9",
+		"388f7b0f632de8140fe337e62a37f3566500a99934c2231b6cb9fd7584b8e672"},
+	{"fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364140",
+		"79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798",
+		"b7c52588d95c3b9aa25b0403f1eef75702e84bb7597aabe663b82f6f04ef2777"},
+}
+
+func TestSecp256k1BaseMultiples(t *testing.T) {
+	c := new(Curve)
+	for _, v := range baseMultiples {
+		k, ok := new(big.Int).SetString(v.k, 16)
+		require.True(t, ok)
+		P := c.Point().Mul(mod.NewInt(k, order), nil)
+
+		buf, err := P.(*point).MarshalUncompressed()
+		require.NoError(t, err)
+		require.Equal(t, "04"+v.x+v.y, hex.EncodeToString(buf))
+
+		buf, err = P.MarshalBinary()
+		require.NoError(t, err)
+		y, _ := new(big.Int).SetString(v.y, 16)
+		prefix := "02"
+		if y.Bit(0) == 1 {
+			prefix = "03"
+		}
+		require.Equal(t, prefix+v.x, hex.EncodeToString(buf))
+	}
+
+	// n*G is the point at infinity
+	nG := c.Point().Mul(mod.NewInt(new(big.Int).Sub(order, big.NewInt(1)), order), nil)
+	nG.Add(nG, c.Point().Base())
+	require.True(t, nG.Equal(c.Point().Null()))
+}
+
+// refPoint is a textbook affine implementation of the curve arithmetic on
+// big.Int, used as an independent reference. A nil x is the point at
+// infinity.
+type refPoint struct{ x, y *big.Int }
+
+var refP, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+
+func refAdd(a, b refPoint) refPoint {
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+	var l *big.Int
+	if a.x.Cmp(b.x) == 0 {
+		if new(big.Int).Add(a.y, b.y).Mod(new(big.Int).Add(a.y, b.y), refP).Sign() == 0 {
+			return refPoint{}
+		}
+		// l = 3x^2 / 2y
+		num := new(big.Int).Mul(a.x, a.x)
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(a.y, 1)
+		l = num.Mul(num, den.ModInverse(den, refP))
+	} else {
+		num := new(big.Int).Sub(b.y, a.y)
+		den := new(big.Int).Sub(b.x, a.x)
+		den.Mod(den, refP)
+		l = num.Mul(num, den.ModInverse(den, refP))
+	}
+	l.Mod(l, refP)
+	x := new(big.Int).Mul(l, l)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, refP)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, l).Sub(y, a.y).Mod(y, refP)
+	return refPoint{x, y}
+}
+
+func refMul(k *big.Int, p refPoint) refPoint {
+	r := refPoint{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = refAdd(r, r)
+		if k.Bit(i) == 1 {
+			r = refAdd(r, p)
+		}
+	}
+	return r
+}
+
+func toRef(t *testing.T, p kyber.Point) refPoint {
+	buf, err := p.(*point).MarshalUncompressed()
+	require.NoError(t, err)
+	if buf[0] == 0 {
+		return refPoint{}
+	}
+	return refPoint{new(big.Int).SetBytes(buf[1:33]), new(big.Int).SetBytes(buf[33:])}
+}
+
+func TestSecp256k1Reference(t *testing.T) {
+	c := new(Curve)
+	stream := tSuite.RandomStream()
+	G := toRef(t, c.Point().Base())
+	for i := 0; i < 10; i++ {
+		a := c.Scalar().Pick(stream)
+		b := c.Scalar().Pick(stream)
+		A := c.Point().Mul(a, nil)
+		B := c.Point().Mul(b, nil)
+		refA := refMul(&a.(*mod.Int).V, G)
+		require.Equal(t, refA, toRef(t, A))
+		require.Equal(t, refAdd(refA, toRef(t, B)), toRef(t, c.Point().Add(A, B)))
+		require.Equal(t, refAdd(refA, refA), toRef(t, c.Point().Add(A, A)))
+
+		// ECDH: both parties derive the same secret, matching the reference
+		sharedA := c.Point().Mul(a, B)
+		sharedB := c.Point().Mul(b, A)
+		require.True(t, sharedA.Equal(sharedB))
+		require.Equal(t, refMul(&a.(*mod.Int).V, toRef(t, B)), toRef(t, sharedA))
+	}
+}
+
+func TestSecp256k1Encoding(t *testing.T) {
+	c := new(Curve)
+	stream := tSuite.RandomStream()
+	for i := 0; i < 20; i++ {
+		P := c.Point().Pick(stream)
+		compressed, err := P.MarshalBinary()
+		require.NoError(t, err)
+		require.Len(t, compressed, c.PointLen())
+		uncompressed, err := P.(*point).MarshalUncompressed()
+		require.NoError(t, err)
+
+		Q := c.Point()
+		require.NoError(t, Q.UnmarshalBinary(compressed))
+		require.True(t, P.Equal(Q))
+		R := c.Point()
+		require.NoError(t, R.UnmarshalBinary(uncompressed))
+		require.True(t, P.Equal(R))
+	}
+
+	null, err := c.Point().Null().MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, compressedLen), null)
+	P := c.Point().Base()
+	require.NoError(t, P.UnmarshalBinary(null))
+	require.True(t, P.Equal(c.Point().Null()))
+
+	base, _ := c.Point().Base().MarshalBinary()
+	invalid := append([]byte{}, base...)
+	invalid[0] = 5
+	require.Error(t, c.Point().UnmarshalBinary(invalid))
+	// x = p is not canonical
+	invalid = append([]byte{2}, refP.Bytes()...)
+	require.Error(t, c.Point().UnmarshalBinary(invalid))
+	// x = 5 is not the x-coordinate of a point, 5^3+7 = 132 is not a square
+	invalid = make([]byte, compressedLen)
+	invalid[0], invalid[32] = 2, 5
+	require.Error(t, c.Point().UnmarshalBinary(invalid))
+	uncompressed, _ := c.Point().Base().(*point).MarshalUncompressed()
+	uncompressed[64] ^= 1
+	require.Error(t, c.Point().UnmarshalBinary(uncompressed))
+	require.Error(t, c.Point().UnmarshalBinary(base[:32]))
+}
+
+func TestSecp256k1IsIdentity(t *testing.T) {
+	c := new(Curve)
+	require.True(t, c.Point().(*point).IsIdentity())
+	require.False(t, c.Point().Base().(*point).IsIdentity())
+	P := c.Point().Pick(tSuite.RandomStream())
+	require.True(t, c.Point().Sub(P, P).(*point).IsIdentity())
+	require.False(t, P.(*point).IsIdentity())
+}
+
+func TestSecp256k1Embed(t *testing.T) {
+	c := new(Curve)
+	data := []byte("secp256k1 embedded data")
+	P := c.Point().Embed(data, tSuite.RandomStream())
+	out, err := P.Data()
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(data, out))
+}
+
+func BenchmarkSecp256k1Mul(b *testing.B) {
+	c := new(Curve)
+	s := c.Scalar().Pick(tSuite.RandomStream())
+	P := c.Point().Pick(tSuite.RandomStream())
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		P.Mul(s, P)
+	}
+}
@@ -0,0 +1,215 @@
+package secp256k1
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// fieldElement is an element of GF(p), p = 2^256 - 2^32 - 977, stored as
+// four little-endian 64-bit limbs. All the operations below keep their
+// result fully reduced and run in time independent of the values they
+// operate on.
+type fieldElement [4]uint64
+
+// fieldC is 2^256 mod p.
+const fieldC = 0x1000003d1
+
+// fieldP holds the limbs of p.
+var fieldP = fieldElement{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+// fieldB3 is 3*b = 21, where b = 7 is the constant of the curve equation.
+var fieldB3 = fieldElement{21, 0, 0, 0}
+
+func (z *fieldElement) zero() *fieldElement {
+	*z = fieldElement{}
+	return z
+}
+
+func (z *fieldElement) one() *fieldElement {
+	*z = fieldElement{1, 0, 0, 0}
+	return z
+}
+
+// reduceOnce subtracts p from a value known to be below 2p, if needed. The
+// carry argument is the bit above the four limbs of z.
+func (z *fieldElement) reduceOnce(carry uint64) {
+	// z - p = z + 2^256 - p - 2^256 = z + fieldC - 2^256
+	var t fieldElement
+	var c uint64
+	t[0], c = bits.Add64(z[0], fieldC, 0)
+	t[1], c = bits.Add64(z[1], 0, c)
+	t[2], c = bits.Add64(z[2], 0, c)
+	t[3], c = bits.Add64(z[3], 0, c)
+	z.cmov(&t, int((carry|c)&1))
+}
+
+func (z *fieldElement) add(a, b *fieldElement) *fieldElement {
+	var c uint64
+	z[0], c = bits.Add64(a[0], b[0], 0)
+	z[1], c = bits.Add64(a[1], b[1], c)
+	z[2], c = bits.Add64(a[2], b[2], c)
+	z[3], c = bits.Add64(a[3], b[3], c)
+	z.reduceOnce(c)
+	return z
+}
+
+func (z *fieldElement) sub(a, b *fieldElement) *fieldElement {
+	var borrow uint64
+	z[0], borrow = bits.Sub64(a[0], b[0], 0)
+	z[1], borrow = bits.Sub64(a[1], b[1], borrow)
+	z[2], borrow = bits.Sub64(a[2], b[2], borrow)
+	z[3], borrow = bits.Sub64(a[3], b[3], borrow)
+	// on borrow, add p, i.e. subtract fieldC modulo 2^256
+	mask := -borrow
+	z[0], borrow = bits.Sub64(z[0], fieldC&mask, 0)
+	z[1], borrow = bits.Sub64(z[1], 0, borrow)
+	z[2], borrow = bits.Sub64(z[2], 0, borrow)
+	z[3], _ = bits.Sub64(z[3], 0, borrow)
+	return z
+}
+
+func (z *fieldElement) neg(a *fieldElement) *fieldElement {
+	var zero fieldElement
+	return z.sub(&zero, a)
+}
+
+func (z *fieldElement) mul(a, b *fieldElement) *fieldElement {
+	var r [8]uint64
+	for i := 0; i < 4; i++ {
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[i], b[j])
+			var c uint64
+			lo, c = bits.Add64(lo, r[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			r[i+j] = lo
+			carry = hi
+		}
+		r[i+4] = carry
+	}
+
+	// r = lo + hi*2^256 = lo + hi*fieldC mod p
+	var t [5]uint64
+	var carry uint64
+	for i := 0; i < 4; i++ {
+		hi, lo := bits.Mul64(r[4+i], fieldC)
+		var c uint64
+		lo, c = bits.Add64(lo, r[i], 0)
+		hi += c
+		lo, c = bits.Add64(lo, carry, 0)
+		hi += c
+		t[i] = lo
+		carry = hi
+	}
+	t[4] = carry
+
+	// fold the 34 bits above 2^256 once more
+	hi, lo := bits.Mul64(t[4], fieldC)
+	var c uint64
+	z[0], c = bits.Add64(t[0], lo, 0)
+	z[1], c = bits.Add64(t[1], hi, c)
+	z[2], c = bits.Add64(t[2], 0, c)
+	z[3], c = bits.Add64(t[3], 0, c)
+	// a last carry leaves a small value behind, adding fieldC can't overflow
+	z[0], c = bits.Add64(z[0], fieldC&-c, 0)
+	z[1], c = bits.Add64(z[1], 0, c)
+	z[2], c = bits.Add64(z[2], 0, c)
+	z[3], _ = bits.Add64(z[3], 0, c)
+	z.reduceOnce(0)
+	return z
+}
+
+func (z *fieldElement) square(a *fieldElement) *fieldElement {
+	return z.mul(a, a)
+}
+
+// exp sets z to a^e. The exponent e is public, only a is protected.
+func (z *fieldElement) exp(a *fieldElement, e *fieldElement) *fieldElement {
+	var r fieldElement
+	r.one()
+	base := *a
+	for i := 3; i >= 0; i-- {
+		for j := 63; j >= 0; j-- {
+			r.square(&r)
+			if (e[i]>>uint(j))&1 == 1 {
+				r.mul(&r, &base)
+			}
+		}
+	}
+	*z = r
+	return z
+}
+
+// fieldPMinus2 is the exponent for inversion by Fermat's little theorem.
+var fieldPMinus2 = fieldElement{0xfffffffefffffc2d, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+// fieldSqrtExp is (p+1)/4, the exponent of a square root since p = 3 mod 4.
+var fieldSqrtExp = fieldElement{0xffffffffbfffff0c, 0xffffffffffffffff, 0xffffffffffffffff, 0x3fffffffffffffff}
+
+// invert sets z to 1/a, or to zero if a is zero.
+func (z *fieldElement) invert(a *fieldElement) *fieldElement {
+	return z.exp(a, &fieldPMinus2)
+}
+
+// sqrt sets z to a square root of a and returns 1 if a is a square, or
+// returns 0 and leaves z with an unspecified value otherwise.
+func (z *fieldElement) sqrt(a *fieldElement) int {
+	var r, check fieldElement
+	r.exp(a, &fieldSqrtExp)
+	check.square(&r)
+	*z = r
+	return check.equal(a)
+}
+
+// equal returns 1 if z and a are equal and 0 otherwise.
+func (z *fieldElement) equal(a *fieldElement) int {
+	var d uint64
+	for i := range z {
+		d |= z[i] ^ a[i]
+	}
+	return int(1 ^ ((d | -d) >> 63))
+}
+
+// isZero returns 1 if z is zero and 0 otherwise.
+func (z *fieldElement) isZero() int {
+	var zero fieldElement
+	return z.equal(&zero)
+}
+
+// isOdd returns the least significant bit of z.
+func (z *fieldElement) isOdd() int {
+	return int(z[0] & 1)
+}
+
+// cmov sets z to a if cond is 1 and leaves it unchanged if cond is 0.
+func (z *fieldElement) cmov(a *fieldElement, cond int) {
+	mask := -uint64(cond)
+	for i := range z {
+		z[i] ^= mask & (z[i] ^ a[i])
+	}
+}
+
+// setBytes sets z to the 32-byte big-endian value b and returns 0 if that
+// value is not below p, in which case z is left unchanged.
+func (z *fieldElement) setBytes(b []byte) int {
+	var t fieldElement
+	for i := 0; i < 4; i++ {
+		t[i] = binary.BigEndian.Uint64(b[24-8*i:])
+	}
+	var borrow uint64
+	_, borrow = bits.Sub64(t[0], fieldP[0], 0)
+	_, borrow = bits.Sub64(t[1], fieldP[1], borrow)
+	_, borrow = bits.Sub64(t[2], fieldP[2], borrow)
+	_, borrow = bits.Sub64(t[3], fieldP[3], borrow)
+	z.cmov(&t, int(borrow))
+	return int(borrow)
+}
+
+// bytes writes the 32-byte big-endian encoding of z to b.
+func (z *fieldElement) bytes(b []byte) {
+	for i := 0; i < 4; i++ {
+		binary.BigEndian.PutUint64(b[24-8*i:], z[i])
+	}
+}
@@ -0,0 +1,362 @@
+package secp256k1
+
+import (
+	"crypto/cipher"
+	"encoding/hex"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/internal/marshalling"
+	"go.dedis.ch/kyber/v3/group/mod"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+// Sizes of the SEC1 encodings of a point.
+const (
+	compressedLen   = 33
+	uncompressedLen = 65
+)
+
+// point is a point of secp256k1, y^2 = x^3 + 7, in projective coordinates
+// (X:Y:Z) with x = X/Z and y = Y/Z. The point at infinity is (0:1:0).
+type point struct {
+	x, y, z fieldElement
+}
+
+// baseX and baseY are the affine coordinates of the standard base point.
+var baseX, baseY fieldElement
+
+func init() {
+	gx, _ := hex.DecodeString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	gy, _ := hex.DecodeString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8")
+	baseX.setBytes(gx)
+	baseY.setBytes(gy)
+}
+
+func (p *point) String() string {
+	b, _ := p.MarshalBinary()
+	return hex.EncodeToString(b)
+}
+
+// Equal compares the points in constant time by cross-multiplying their
+// coordinates, without normalizing them.
+func (p *point) Equal(p2 kyber.Point) bool {
+	q := p2.(*point)
+	var l, r fieldElement
+	l.mul(&p.x, &q.z)
+	r.mul(&q.x, &p.z)
+	eq := l.equal(&r)
+	l.mul(&p.y, &q.z)
+	r.mul(&q.y, &p.z)
+	return eq&l.equal(&r) == 1
+}
+
+// IsIdentity returns true if p is the point at infinity, in constant time.
+func (p *point) IsIdentity() bool {
+	return p.z.isZero() == 1
+}
+
+func (p *point) Null() kyber.Point {
+	p.x.zero()
+	p.y.one()
+	p.z.zero()
+	return p
+}
+
+func (p *point) Base() kyber.Point {
+	p.x = baseX
+	p.y = baseY
+	p.z.one()
+	return p
+}
+
+// affine returns the affine coordinates of p, and 1 if p is the point at
+// infinity, in which case the coordinates are zero.
+func (p *point) affine() (x, y fieldElement, inf int) {
+	var zinv fieldElement
+	zinv.invert(&p.z)
+	x.mul(&p.x, &zinv)
+	y.mul(&p.y, &zinv)
+	return x, y, p.z.isZero()
+}
+
+// setAffine sets p to (x, y), after checking that it is on the curve.
+func (p *point) setAffine(x, y *fieldElement) error {
+	var lhs, rhs fieldElement
+	lhs.square(y)
+	curveRHS(&rhs, x)
+	if lhs.equal(&rhs) != 1 {
+		return errors.New("secp256k1: point is not on the curve")
+	}
+	p.x = *x
+	p.y = *y
+	p.z.one()
+	return nil
+}
+
+// curveRHS sets z to x^3 + 7.
+func curveRHS(z, x *fieldElement) {
+	var t, seven fieldElement
+	seven[0] = 7
+	t.square(x)
+	t.mul(&t, x)
+	z.add(&t, &seven)
+}
+
+func (p *point) EmbedLen() int {
+	// Reserve the most significant 8 bits for randomness and the least
+	// significant 8 bits for the length of the embedded data.
+	return (256 - 8 - 8) / 8
+}
+
+func (p *point) Pick(rand cipher.Stream) kyber.Point {
+	return p.Embed(nil, rand)
+}
+
+// Embed picks a point whose x-coordinate contains up to EmbedLen bytes of
+// data. The remaining bits of the point are chosen randomly.
+func (p *point) Embed(data []byte, rand cipher.Stream) kyber.Point {
+	dl := p.EmbedLen()
+	if dl > len(data) {
+		dl = len(data)
+	}
+	for {
+		b := random.Bits(256, false, rand)
+		if data != nil {
+			b[31] = byte(dl)
+			copy(b[31-dl:31], data)
+		}
+		var x, y, y2 fieldElement
+		if x.setBytes(b) != 1 {
+			continue
+		}
+		curveRHS(&y2, &x)
+		if y.sqrt(&y2) != 1 {
+			continue
+		}
+		// pick a random sign for the y-coordinate
+		var sign [1]byte
+		rand.XORKeyStream(sign[:], sign[:])
+		var negY fieldElement
+		negY.neg(&y)
+		y.cmov(&negY, int(sign[0]>>7))
+		p.x = x
+		p.y = y
+		p.z.one()
+		return p
+	}
+}
+
+// Data extracts the data embedded with Embed.
+func (p *point) Data() ([]byte, error) {
+	x, _, _ := p.affine()
+	var b [32]byte
+	x.bytes(b[:])
+	dl := int(b[31])
+	if dl > p.EmbedLen() {
+		return nil, errors.New("secp256k1: invalid embedded data length")
+	}
+	return b[31-dl : 31], nil
+}
+
+// Add uses the complete addition formulas of Renes, Costello and Batina,
+// "Complete addition formulas for prime order elliptic curves", algorithm 7,
+// which also handle doubling and the point at infinity without branching.
+func (p *point) Add(a, b kyber.Point) kyber.Point {
+	p1 := a.(*point)
+	p2 := b.(*point)
+	var t0, t1, t2, t3, t4, x3, y3, z3 fieldElement
+
+	t0.mul(&p1.x, &p2.x)
+	t1.mul(&p1.y, &p2.y)
+	t2.mul(&p1.z, &p2.z)
+	t3.add(&p1.x, &p1.y)
+	t4.add(&p2.x, &p2.y)
+	t3.mul(&t3, &t4)
+	t4.add(&t0, &t1)
+	t3.sub(&t3, &t4)
+	t4.add(&p1.y, &p1.z)
+	x3.add(&p2.y, &p2.z)
+	t4.mul(&t4, &x3)
+	x3.add(&t1, &t2)
+	t4.sub(&t4, &x3)
+	x3.add(&p1.x, &p1.z)
+	y3.add(&p2.x, &p2.z)
+	x3.mul(&x3, &y3)
+	y3.add(&t0, &t2)
+	y3.sub(&x3, &y3)
+	x3.add(&t0, &t0)
+	t0.add(&x3, &t0)
+	t2.mul(&fieldB3, &t2)
+	z3.add(&t1, &t2)
+	t1.sub(&t1, &t2)
+	y3.mul(&fieldB3, &y3)
+	x3.mul(&t4, &y3)
+	t2.mul(&t3, &t1)
+	x3.sub(&t2, &x3)
+	y3.mul(&y3, &t0)
+	t1.mul(&t1, &z3)
+	y3.add(&t1, &y3)
+	t0.mul(&t0, &t3)
+	z3.mul(&z3, &t4)
+	z3.add(&z3, &t0)
+
+	p.x, p.y, p.z = x3, y3, z3
+	return p
+}
+
+func (p *point) Sub(a, b kyber.Point) kyber.Point {
+	var nb point
+	nb.Neg(b)
+	return p.Add(a, &nb)
+}
+
+func (p *point) Neg(a kyber.Point) kyber.Point {
+	q := a.(*point)
+	p.x = q.x
+	p.y.neg(&q.y)
+	p.z = q.z
+	return p
+}
+
+// Mul sets p to s*b, or to s times the base point if b is nil. It performs
+// a double-and-add-always over all the 256 bits of s, selecting the result
+// of each addition in constant time.
+func (p *point) Mul(s kyber.Scalar, b kyber.Point) kyber.Point {
+	var q point
+	if b == nil {
+		q.Base()
+	} else {
+		q.Set(b)
+	}
+	var k [32]byte
+	scalarBytes(s.(*mod.Int), k[:])
+
+	var r, sum point
+	r.Null()
+	for i := 0; i < 256; i++ {
+		bit := int(k[i/8]>>uint(7-i%8)) & 1
+		r.Add(&r, &r)
+		sum.Add(&r, &q)
+		r.cmov(&sum, bit)
+	}
+	*p = r
+	return p
+}
+
+// scalarBytes writes the 32-byte big-endian encoding of s to b.
+func scalarBytes(s *mod.Int, b []byte) {
+	v := s.V.Bytes()
+	for i := range b {
+		b[i] = 0
+	}
+	copy(b[len(b)-len(v):], v)
+}
+
+// cmov sets p to q if cond is 1 and leaves it unchanged if cond is 0.
+func (p *point) cmov(q *point, cond int) {
+	p.x.cmov(&q.x, cond)
+	p.y.cmov(&q.y, cond)
+	p.z.cmov(&q.z, cond)
+}
+
+func (p *point) Set(q kyber.Point) kyber.Point {
+	*p = *q.(*point)
+	return p
+}
+
+func (p *point) Clone() kyber.Point {
+	q := *p
+	return &q
+}
+
+// MarshalSize returns 33, the size of the compressed SEC1 encoding.
+func (p *point) MarshalSize() int {
+	return compressedLen
+}
+
+// MarshalBinary returns the compressed SEC1 encoding of the point. The point
+// at infinity, which has no encoding of this size in SEC1, is encoded as 33
+// zero bytes.
+func (p *point) MarshalBinary() ([]byte, error) {
+	x, y, inf := p.affine()
+	b := make([]byte, compressedLen)
+	b[0] = byte(2 | y.isOdd())
+	x.bytes(b[1:])
+	if inf == 1 {
+		for i := range b {
+			b[i] = 0
+		}
+	}
+	return b, nil
+}
+
+// MarshalUncompressed returns the uncompressed SEC1 encoding of the point,
+// 0x04 followed by both coordinates. The point at infinity is encoded as 65
+// zero bytes.
+func (p *point) MarshalUncompressed() ([]byte, error) {
+	x, y, inf := p.affine()
+	b := make([]byte, uncompressedLen)
+	b[0] = 4
+	x.bytes(b[1:33])
+	y.bytes(b[33:])
+	if inf == 1 {
+		for i := range b {
+			b[i] = 0
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary accepts both the compressed and the uncompressed SEC1
+// encodings of a point, as well as the all-zero encodings of the point at
+// infinity produced by MarshalBinary and MarshalUncompressed.
+func (p *point) UnmarshalBinary(buf []byte) error {
+	if len(buf) != compressedLen && len(buf) != uncompressedLen {
+		return errors.New("secp256k1: invalid point encoding length")
+	}
+	var c byte
+	for _, b := range buf {
+		c |= b
+	}
+	if c == 0 {
+		p.Null()
+		return nil
+	}
+
+	var x, y fieldElement
+	if x.setBytes(buf[1:33]) != 1 {
+		return errors.New("secp256k1: x-coordinate is not canonical")
+	}
+	if len(buf) == uncompressedLen {
+		if buf[0] != 4 {
+			return errors.New("secp256k1: invalid uncompressed point prefix")
+		}
+		if y.setBytes(buf[33:]) != 1 {
+			return errors.New("secp256k1: y-coordinate is not canonical")
+		}
+		return p.setAffine(&x, &y)
+	}
+
+	if buf[0] != 2 && buf[0] != 3 {
+		return errors.New("secp256k1: invalid compressed point prefix")
+	}
+	var y2 fieldElement
+	curveRHS(&y2, &x)
+	if y.sqrt(&y2) != 1 {
+		return errors.New("secp256k1: point is not on the curve")
+	}
+	var negY fieldElement
+	negY.neg(&y)
+	y.cmov(&negY, y.isOdd()^int(buf[0]&1))
+	return p.setAffine(&x, &y)
+}
+
+func (p *point) MarshalTo(w io.Writer) (int, error) {
+	return marshalling.PointMarshalTo(p, w)
+}
+
+func (p *point) UnmarshalFrom(r io.Reader) (int, error) {
+	return marshalling.PointUnmarshalFrom(p, r)
+}
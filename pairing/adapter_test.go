@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/util/key"
 )
 
@@ -26,3 +27,22 @@ func TestAdapter_SuiteBn256(t *testing.T) {
 
 	require.Equal(t, "bn256.adapter", suite.String())
 }
+
+func TestMillerSuite_Bn256(t *testing.T) {
+	var suite Suite = bn256.NewSuite()
+	_, ok := suite.(MillerSuite)
+	require.True(t, ok)
+}
+
+func TestSwap(t *testing.T) {
+	suite := bn256.NewSuite()
+	swapped := Swap(suite)
+	require.Equal(t, suite.G1().String(), swapped.G2().String())
+	require.Equal(t, suite.G2().String(), swapped.G1().String())
+
+	a := suite.G1().Scalar().Pick(suite.RandomStream())
+	b := suite.G1().Scalar().Pick(suite.RandomStream())
+	pa := swapped.G1().Point().Mul(a, nil)
+	pb := swapped.G2().Point().Mul(b, nil)
+	require.True(t, swapped.Pair(pa, pb).Equal(suite.Pair(pb, pa)))
+}
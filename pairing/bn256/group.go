@@ -2,6 +2,7 @@ package bn256
 
 import (
 	"crypto/cipher"
+	"math/big"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/group/mod"
@@ -24,6 +25,11 @@ func (g *groupG1) Point() kyber.Point {
 	return newPointG1()
 }
 
+// Cofactor returns 1, since the curve of G1 has a prime order.
+func (g *groupG1) Cofactor() *big.Int {
+	return g1Cofactor
+}
+
 type groupG2 struct {
 	common
 	*commonSuite
@@ -41,6 +47,12 @@ func (g *groupG2) Point() kyber.Point {
 	return newPointG2()
 }
 
+// Cofactor returns 2p-n, the cofactor of G2 in the sextic twist of the
+// curve, whose order is n(2p-n) where n is Order.
+func (g *groupG2) Cofactor() *big.Int {
+	return g2Cofactor
+}
+
 type groupGT struct {
 	common
 	*commonSuite
@@ -58,6 +70,20 @@ func (g *groupGT) Point() kyber.Point {
 	return newPointGT()
 }
 
+// Cofactor returns (p¹²-1)/n, the cofactor of GT in the multiplicative group
+// of the field of order p¹².
+func (g *groupGT) Cofactor() *big.Int {
+	return gtCofactor
+}
+
+var (
+	g1Cofactor = big.NewInt(1)
+	g2Cofactor = new(big.Int).Sub(new(big.Int).Lsh(p, 1), Order)
+	gtCofactor = new(big.Int).Quo(
+		new(big.Int).Sub(new(big.Int).Exp(p, big.NewInt(12), nil), big.NewInt(1)),
+		Order)
+)
+
 // common functionalities across G1, G2, and GT
 type common struct{}
 
@@ -69,6 +95,12 @@ func (c *common) Scalar() kyber.Scalar {
 	return mod.NewInt64(0, Order)
 }
 
+// Order returns the prime order n of G1, G2 and GT. Together with Cofactor,
+// it implements the kyber.GroupParameters interface.
+func (c *common) Order() *big.Int {
+	return Order
+}
+
 func (c *common) PrimeOrder() bool {
 	return true
 }
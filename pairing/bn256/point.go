@@ -31,6 +31,11 @@ func (p *pointG1) Equal(q kyber.Point) bool {
 	return subtle.ConstantTimeCompare(x, y) == 1
 }
 
+// IsIdentity returns true if p is the neutral element of G1, in constant time.
+func (p *pointG1) IsIdentity() bool {
+	return p.Equal(newPointG1().Null())
+}
+
 func (p *pointG1) Null() kyber.Point {
 	p.g.SetInfinity()
 	return p
@@ -290,6 +295,92 @@ type pointG2 struct {
 	g *twistPoint
 }
 
+// Hash maps m to a point of G2: a point of the twist is found by
+// try-and-increment from the SHA-256 hashes of m, then multiplied by the
+// cofactor of G2. It is not constant time, which is fine for public inputs
+// such as messages to sign.
+func (p *pointG2) Hash(m []byte) kyber.Point {
+	x, y := hashToTwist(m)
+	if p.g == nil {
+		p.g = new(twistPoint)
+	}
+	t := &twistPoint{*x, *y, gfP2{}, gfP2{}}
+	t.z.SetOne()
+	t.t.SetOne()
+	p.g.Mul(t, g2Cofactor)
+	return p
+}
+
+// hashToTwist returns the affine coordinates of a point of the twist whose
+// x-coordinate is derived from m.
+func hashToTwist(m []byte) (*gfP2, *gfP2) {
+	h0 := sha256.Sum256(m)
+	h1 := sha256.Sum256(h0[:])
+	re := new(big.Int).SetBytes(h0[:])
+	re.Mod(re, p)
+	im := new(big.Int).SetBytes(h1[:])
+	im.Mod(im, p)
+	x := &gfP2{*bigToGFp(im), gfP{}}
+
+	for {
+		x.y = *bigToGFp(re)
+		// y² = x³ + b
+		y2 := (&gfP2{}).Square(x)
+		y2.Mul(y2, x).Add(y2, twistB)
+		if y, ok := gfP2Sqrt(y2); ok {
+			return x, y
+		}
+		re.Add(re, big.NewInt(1))
+		re.Mod(re, p)
+	}
+}
+
+// gfP2Sqrt returns a square root of a, if a is a square. Since p = 3 mod 4,
+// the square root xi+y of ai+b is obtained from square roots in GF(p): with
+// n = sqrt(a²+b²), y² = (b ± n)/2 and x = a/2y.
+func gfP2Sqrt(e *gfP2) (*gfP2, bool) {
+	a, b := e.x.BigInt(), e.y.BigInt()
+	norm := new(big.Int).Mul(a, a)
+	norm.Add(norm, new(big.Int).Mul(b, b))
+	norm.Mod(norm, p)
+	n := new(big.Int).ModSqrt(norm, p)
+	if n == nil {
+		return nil, false
+	}
+	half := new(big.Int).ModInverse(big.NewInt(2), p)
+	for _, sign := range []int{1, -1} {
+		y2 := new(big.Int).Mul(n, big.NewInt(int64(sign)))
+		y2.Add(y2, b)
+		y2.Mul(y2, half)
+		y2.Mod(y2, p)
+		y := new(big.Int).ModSqrt(y2, p)
+		if y == nil || y.Sign() == 0 {
+			continue
+		}
+		x := new(big.Int).Lsh(y, 1)
+		x.ModInverse(x, p)
+		x.Mul(x, a)
+		x.Mod(x, p)
+
+		r := &gfP2{*bigToGFp(x), *bigToGFp(y)}
+		if *(&gfP2{}).Square(r) == *e {
+			return r, true
+		}
+	}
+	return nil, false
+}
+
+// bigToGFp returns x, which must be in [0, p), in Montgomery form.
+func bigToGFp(x *big.Int) *gfP {
+	buf := make([]byte, 32)
+	xb := x.Bytes()
+	copy(buf[32-len(xb):], xb)
+	e := new(gfP)
+	e.Unmarshal(buf)
+	montEncode(e, e)
+	return e
+}
+
 func newPointG2() *pointG2 {
 	p := &pointG2{g: &twistPoint{}}
 	return p
@@ -301,6 +392,11 @@ func (p *pointG2) Equal(q kyber.Point) bool {
 	return subtle.ConstantTimeCompare(x, y) == 1
 }
 
+// IsIdentity returns true if p is the neutral element of G2, in constant time.
+func (p *pointG2) IsIdentity() bool {
+	return p.Equal(newPointG2().Null())
+}
+
 func (p *pointG2) Null() kyber.Point {
 	p.g.SetInfinity()
 	return p
@@ -365,9 +461,12 @@ func (p *pointG2) Mul(s kyber.Scalar, q kyber.Point) kyber.Point {
 	if q == nil {
 		q = newPointG2().Base()
 	}
-	t := s.(*mod.Int).V
+	t := &s.(*mod.Int).V
+	if t.Sign() < 0 || t.BitLen() > 256 {
+		t = new(big.Int).Mod(t, Order)
+	}
 	r := q.(*pointG2).g
-	p.g.Mul(r, &t)
+	p.g.MulConstantTime(r, t)
 	return p
 }
 
@@ -412,7 +511,26 @@ func (p *pointG2) MarshalTo(w io.Writer) (int, error) {
 	return w.Write(buf)
 }
 
+// UnmarshalBinary decodes a point of G2 and checks that it is on the curve
+// and in the subgroup of prime order, which costs a scalar multiplication.
 func (p *pointG2) UnmarshalBinary(buf []byte) error {
+	if err := p.UnmarshalBinaryUnchecked(buf); err != nil {
+		return err
+	}
+	t := &twistPoint{}
+	t.Mul(p.g, Order)
+	if !t.IsInfinity() {
+		return errors.New("bn256.G2: point not in the prime order subgroup")
+	}
+	return nil
+}
+
+// UnmarshalBinaryUnchecked is like UnmarshalBinary but only checks that the
+// point is on the curve of the twist, which also holds points of small
+// order outside G2. It is much faster, but must only be used for data that
+// was already validated, such as the keys stored by a node itself, never
+// for points received from others.
+func (p *pointG2) UnmarshalBinaryUnchecked(buf []byte) error {
 	n := p.ElementSize()
 	if p.g == nil {
 		p.g = &twistPoint{}
@@ -483,6 +601,11 @@ func (p *pointGT) Equal(q kyber.Point) bool {
 	return subtle.ConstantTimeCompare(x, y) == 1
 }
 
+// IsIdentity returns true if p is the neutral element of GT, in constant time.
+func (p *pointGT) IsIdentity() bool {
+	return p.Equal(newPointGT().Null())
+}
+
 func (p *pointGT) Null() kyber.Point {
 	p.g.Set(gfP12Inf)
 	return p
@@ -3,8 +3,10 @@ package bn256
 import (
 	"bytes"
 	"encoding/hex"
-	"go.dedis.ch/kyber/v3/util/random"
+	"math/big"
 	"testing"
+
+	"go.dedis.ch/kyber/v3/util/random"
 )
 
 func TestPointG1_HashToPoint(t *testing.T) {
@@ -54,3 +56,141 @@ func TestPointG1_EmbedData(t *testing.T) {
 		t.Error("G1: Embed/Data produced wrong output: ", string(mm), " expected ", string(m))
 	}
 }
+
+func TestPointG2_MulConstantTime(t *testing.T) {
+	one := big.NewInt(1)
+	max := new(big.Int).Sub(new(big.Int).Lsh(one, 256), one)
+	scalars := []*big.Int{
+		big.NewInt(0), big.NewInt(1), big.NewInt(2), big.NewInt(15), big.NewInt(16),
+		new(big.Int).Sub(Order, one), Order, max,
+	}
+	for i := 0; i < 50; i++ {
+		scalars = append(scalars, random.Int(Order, random.New()))
+	}
+
+	base := newPointG2().Base().(*pointG2).g
+	other := newPointG2().Pick(random.New()).(*pointG2).g
+	for _, a := range []*twistPoint{base, other} {
+		for _, k := range scalars {
+			expected, actual := &twistPoint{}, &twistPoint{}
+			expected.Mul(a, k)
+			actual.MulConstantTime(a, k)
+			expected.MakeAffine()
+			actual.MakeAffine()
+			if *expected != *actual {
+				t.Fatalf("MulConstantTime differs from Mul for scalar %v", k)
+			}
+		}
+	}
+
+	// the point at infinity
+	inf, res := &twistPoint{}, &twistPoint{}
+	inf.SetInfinity()
+	res.MulConstantTime(inf, scalars[len(scalars)-1])
+	if !res.IsInfinity() {
+		t.Fatal("multiple of infinity is not infinity")
+	}
+}
+
+func BenchmarkPointG2_Mul(b *testing.B) {
+	a := newPointG2().Pick(random.New()).(*pointG2).g
+	k := random.Int(Order, random.New())
+	c := &twistPoint{}
+	b.Run("double-and-add", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.Mul(a, k)
+		}
+	})
+	b.Run("constant-time", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			c.MulConstantTime(a, k)
+		}
+	})
+}
+
+func TestPointG2_Hash(t *testing.T) {
+	h := newPointG2().Hash([]byte("abc")).(*pointG2)
+	if !h.Equal(newPointG2().Hash([]byte("abc"))) {
+		t.Fatal("Hash is not deterministic")
+	}
+	if h.Equal(newPointG2().Hash([]byte("abd"))) {
+		t.Fatal("different messages hash to the same point")
+	}
+	if h.IsIdentity() {
+		t.Fatal("hash is the identity")
+	}
+	if !h.g.Clone().IsOnCurve() {
+		t.Fatal("hash is not on the curve")
+	}
+	// the hash is in G2
+	n := &twistPoint{}
+	n.Mul(h.g, Order)
+	if !n.IsInfinity() {
+		t.Fatal("hash is not in G2")
+	}
+	// and its encoding is read back
+	buf, err := h.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newPointG2().UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// twistPointOutsideG2 returns the encoding of a point of the twist which is
+// not in G2, as the hash to the twist before the cofactor is cleared.
+func twistPointOutsideG2(t testing.TB) []byte {
+	x, y := hashToTwist([]byte("outside"))
+	p := &pointG2{g: &twistPoint{*x, *y, gfP2{}, gfP2{}}}
+	p.g.z.SetOne()
+	p.g.t.SetOne()
+	buf, err := p.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return buf
+}
+
+func TestPointG2_UnmarshalSubgroup(t *testing.T) {
+	outside := twistPointOutsideG2(t)
+	if err := newPointG2().UnmarshalBinary(outside); err == nil {
+		t.Fatal("point outside G2 accepted")
+	}
+	if err := newPointG2().UnmarshalBinaryUnchecked(outside); err != nil {
+		t.Fatal(err)
+	}
+
+	buf, err := newPointG2().Pick(random.New()).MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := newPointG2().UnmarshalBinary(buf); err != nil {
+		t.Fatal(err)
+	}
+	if err := newPointG2().UnmarshalBinaryUnchecked(buf); err != nil {
+		t.Fatal(err)
+	}
+	null, _ := newPointG2().Null().MarshalBinary()
+	if err := newPointG2().UnmarshalBinary(null); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func BenchmarkPointG2_Unmarshal(b *testing.B) {
+	buf, err := newPointG2().Pick(random.New()).MarshalBinary()
+	if err != nil {
+		b.Fatal(err)
+	}
+	p := newPointG2()
+	b.Run("checked", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = p.UnmarshalBinary(buf)
+		}
+	})
+	b.Run("unchecked", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = p.UnmarshalBinaryUnchecked(buf)
+		}
+	})
+}
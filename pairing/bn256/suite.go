@@ -98,6 +98,39 @@ func (s *Suite) Pair(p1 kyber.Point, p2 kyber.Point) kyber.Point {
 	return s.GT().Point().(*pointGT).Pair(p1, p2)
 }
 
+// MillerProduct computes the product of the Miller loops of the pairs
+// (p1s[i], p2s[i]), with p1s in G1 and p2s in G2, without the final
+// exponentiation. The result is not an element of GT: it can only be
+// combined with other Miller products using Add before being passed to
+// FinalExponentiate, which then equals the product of the pairings. This
+// spends a single final exponentiation on a product of several pairings.
+// MillerProduct panics if the two slices have different lengths.
+func (s *Suite) MillerProduct(p1s, p2s []kyber.Point) kyber.Point {
+	if len(p1s) != len(p2s) {
+		panic("bn256: mismatched number of G1 and G2 points")
+	}
+	ret := newPointGT()
+	ret.g.SetOne()
+	for i := range p1s {
+		a := p1s[i].(*pointG1).g
+		b := p2s[i].(*pointG2).g
+		// the pairing with the point at infinity is the identity
+		if a.IsInfinity() || b.IsInfinity() {
+			continue
+		}
+		ret.g.Mul(ret.g, miller(b, a))
+	}
+	return ret
+}
+
+// FinalExponentiate returns the final exponentiation of the output of
+// MillerProduct, which is an element of GT.
+func (s *Suite) FinalExponentiate(p kyber.Point) kyber.Point {
+	ret := newPointGT()
+	ret.g.Set(finalExponentiation(p.(*pointGT).g))
+	return ret
+}
+
 // Not used other than for reflect.TypeOf()
 var aScalar kyber.Scalar
 var aPoint kyber.Point
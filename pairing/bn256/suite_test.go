@@ -3,6 +3,7 @@ package bn256
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -239,6 +240,57 @@ func TestBilinearity(t *testing.T) {
 	require.Equal(t, pc, pd)
 }
 
+func TestMillerProduct(t *testing.T) {
+	suite := NewSuite()
+	stream := random.New()
+	p1s := make([]kyber.Point, 3)
+	p2s := make([]kyber.Point, 3)
+	naive := suite.GT().Point().Null()
+	for i := range p1s {
+		p1s[i] = suite.G1().Point().Pick(stream)
+		p2s[i] = suite.G2().Point().Pick(stream)
+		naive.Add(naive, suite.Pair(p1s[i], p2s[i]))
+	}
+	product := suite.FinalExponentiate(suite.MillerProduct(p1s, p2s))
+	require.True(t, naive.Equal(product))
+
+	// pairs with the point at infinity do not change the product
+	p1s = append(p1s, suite.G1().Point().Null())
+	p2s = append(p2s, suite.G2().Point().Pick(stream))
+	product = suite.FinalExponentiate(suite.MillerProduct(p1s, p2s))
+	require.True(t, naive.Equal(product))
+
+	// e(a*g1, g2) * e(-g1, a*g2) == 1
+	a := suite.G1().Scalar().Pick(stream)
+	lhs := []kyber.Point{
+		suite.G1().Point().Mul(a, nil),
+		suite.G1().Point().Neg(suite.G1().Point().Base()),
+	}
+	rhs := []kyber.Point{
+		suite.G2().Point().Base(),
+		suite.G2().Point().Mul(a, nil),
+	}
+	one := suite.FinalExponentiate(suite.MillerProduct(lhs, rhs))
+	require.True(t, one.Equal(suite.GT().Point().Null()))
+
+	require.Panics(t, func() { suite.MillerProduct(lhs, rhs[:1]) })
+}
+
+func TestIsIdentity(t *testing.T) {
+	suite := NewSuite()
+	stream := random.New()
+	type identityChecker interface {
+		IsIdentity() bool
+	}
+	for _, g := range []kyber.Group{suite.G1(), suite.G2(), suite.GT()} {
+		require.True(t, g.Point().Null().(identityChecker).IsIdentity())
+		require.False(t, g.Point().Base().(identityChecker).IsIdentity())
+		p := g.Point().Pick(stream)
+		require.False(t, p.(identityChecker).IsIdentity())
+		require.True(t, g.Point().Sub(p, p).(identityChecker).IsIdentity())
+	}
+}
+
 func TestTripartiteDiffieHellman(t *testing.T) {
 	suite := NewSuite()
 	a := suite.G1().Scalar().Pick(random.New())
@@ -446,3 +498,21 @@ func BenchmarkBn256(b *testing.B) {
 		}
 	})
 }
+
+func TestGroupParameters(t *testing.T) {
+	suite := NewSuite()
+	g1 := suite.G1().(kyber.GroupParameters)
+	g2 := suite.G2().(kyber.GroupParameters)
+	gt := suite.GT().(kyber.GroupParameters)
+	for _, g := range []kyber.GroupParameters{g1, g2, gt} {
+		require.Equal(t, 0, g.Order().Cmp(Order))
+	}
+	require.Equal(t, int64(1), g1.Cofactor().Int64())
+
+	// #E'(Fp²) = n(2p-n) for the sextic twist of a BN curve
+	twist := new(big.Int).Mul(Order, new(big.Int).Sub(new(big.Int).Lsh(p, 1), Order))
+	require.Equal(t, 0, new(big.Int).Mul(g2.Order(), g2.Cofactor()).Cmp(twist))
+
+	p12 := new(big.Int).Exp(p, big.NewInt(12), nil)
+	require.Equal(t, 0, new(big.Int).Mul(gt.Order(), gt.Cofactor()).Cmp(p12.Sub(p12, big.NewInt(1))))
+}
@@ -172,6 +172,102 @@ func (c *twistPoint) Mul(a *twistPoint, scalar *big.Int) {
 	c.Set(sum)
 }
 
+// mulWindow is the width of the signed digits used by MulConstantTime.
+const mulWindow = 4
+
+// MulConstantTime sets c to scalar*a, where scalar must be smaller than
+// 2^256. Unlike Mul, the sequence of point operations does not depend on
+// the value of scalar: it is recoded into 64 odd signed digits of 4 bits,
+// and the multiples of a are read from a table with constant-time
+// selections. The addition formula still has branches for the point at
+// infinity and for equal inputs, which only occur for a point a of small
+// order or with negligible probability.
+func (c *twistPoint) MulConstantTime(a *twistPoint, scalar *big.Int) {
+	// k is the scalar as 256-bit little-endian limbs, made odd by adding
+	// one if it is even; a is subtracted back at the end in that case
+	var buf [32]byte
+	b := scalar.Bytes()
+	copy(buf[32-len(b):], b)
+	var k [4]uint64
+	for i := range k {
+		for j := 0; j < 8; j++ {
+			k[i] |= uint64(buf[31-8*i-j]) << (8 * uint(j))
+		}
+	}
+	even := 1 - k[0]&1
+	k[0] |= 1
+
+	// table[i] = (2i+1)*a
+	var table [1 << (mulWindow - 1)]twistPoint
+	double := &twistPoint{}
+	double.Double(a)
+	table[0].Set(a)
+	for i := 1; i < len(table); i++ {
+		table[i].Add(&table[i-1], double)
+	}
+
+	// regular recoding: every digit is odd, in [-15, 15]
+	const digits = 256 / mulWindow
+	var recoded [digits]uint64
+	for i := 0; i < digits-1; i++ {
+		recoded[i] = k[0]&(1<<(mulWindow+1)-1) - 1<<mulWindow
+		k[0] = k[0]&^(1<<(mulWindow+1)-1) | 1<<mulWindow
+		for j := 0; j < 3; j++ {
+			k[j] = k[j]>>mulWindow | k[j+1]<<(64-mulWindow)
+		}
+		k[3] >>= mulWindow
+	}
+	recoded[digits-1] = k[0]
+
+	sum, t := &twistPoint{}, &twistPoint{}
+	sum.selectDigit(&table, recoded[digits-1])
+	for i := digits - 2; i >= 0; i-- {
+		for j := 0; j < mulWindow; j++ {
+			sum.Double(sum)
+		}
+		t.selectDigit(&table, recoded[i])
+		sum.Add(sum, t)
+	}
+
+	t.Neg(a)
+	t.Add(sum, t)
+	sum.conditionalSet(t, even)
+	c.Set(sum)
+}
+
+// selectDigit sets c to d*a, where d is an odd digit in [-15, 15] encoded
+// in two's complement and table[i] = (2i+1)*a, in constant time.
+func (c *twistPoint) selectDigit(table *[1 << (mulWindow - 1)]twistPoint, d uint64) {
+	negative := d >> 63
+	abs := (d ^ -negative) + negative
+	idx := abs >> 1
+	c.SetInfinity()
+	for i := range table {
+		c.conditionalSet(&table[i], ctEqual(uint64(i), idx))
+	}
+	neg := &twistPoint{}
+	neg.Neg(c)
+	c.conditionalSet(neg, negative)
+}
+
+// conditionalSet sets c to a if cond is 1 and leaves it unchanged if cond is
+// 0, in constant time.
+func (c *twistPoint) conditionalSet(a *twistPoint, cond uint64) {
+	mask := -cond
+	for _, e := range [][2]*gfP2{{&c.x, &a.x}, {&c.y, &a.y}, {&c.z, &a.z}, {&c.t, &a.t}} {
+		for i := 0; i < 4; i++ {
+			e[0].x[i] ^= mask & (e[0].x[i] ^ e[1].x[i])
+			e[0].y[i] ^= mask & (e[0].y[i] ^ e[1].y[i])
+		}
+	}
+}
+
+// ctEqual returns 1 if a == b and 0 otherwise, in constant time.
+func ctEqual(a, b uint64) uint64 {
+	x := a ^ b
+	return 1 ^ (x|-x)>>63
+}
+
 func (c *twistPoint) MakeAffine() {
 	if c.z.IsOne() {
 		return
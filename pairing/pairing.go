@@ -15,3 +15,17 @@ type Suite interface {
 	kyber.XOFFactory
 	kyber.Random
 }
+
+// MillerSuite is an optional interface of pairing suites that can split the
+// computation of a pairing into its Miller loop and its final
+// exponentiation. Custom verification equations of the form
+// e(a₁,b₁)·…·e(aₙ,bₙ) = 1 can then be checked with a single final
+// exponentiation, using FinalExponentiate(MillerProduct(as, bs)).
+type MillerSuite interface {
+	// MillerProduct returns the product of the Miller loops of the pairs
+	// (p1s[i], p2s[i]). The result is not finalized and is not a valid
+	// element of GT.
+	MillerProduct(p1s, p2s []kyber.Point) kyber.Point
+	// FinalExponentiate maps the output of MillerProduct to GT.
+	FinalExponentiate(p kyber.Point) kyber.Point
+}
@@ -0,0 +1,28 @@
+package pairing
+
+import "go.dedis.ch/kyber/v3"
+
+// Swap returns a pairing suite in which the groups G1 and G2 of s are
+// exchanged, and whose pairing takes its arguments in the swapped order. It
+// lets schemes written for one placement of their points use the other one,
+// e.g. BLS signatures with public keys on G1 and signatures on G2, which
+// makes public keys smaller and signatures larger.
+func Swap(s Suite) Suite {
+	return &swapped{s}
+}
+
+type swapped struct {
+	Suite
+}
+
+func (s *swapped) G1() kyber.Group {
+	return s.Suite.G2()
+}
+
+func (s *swapped) G2() kyber.Group {
+	return s.Suite.G1()
+}
+
+func (s *swapped) Pair(p1, p2 kyber.Point) kyber.Point {
+	return s.Suite.Pair(p2, p1)
+}
@@ -16,6 +16,7 @@ type hashProver struct {
 	msg     bytes.Buffer
 	pubrand kyber.XOF
 	prirand io.Reader
+	obs     TranscriptObserver
 }
 
 // cipherStreamReader adds a Read method onto a cipher.Stream,
@@ -31,10 +32,46 @@ func (s *cipherStreamReader) Read(in []byte) (int, error) {
 	return len(in), nil
 }
 
-func newHashProver(suite Suite, protoName string) *hashProver {
+// TranscriptObserver is called by a hash-based prover or verifier with each
+// element of the Fiat-Shamir transcript, for instance to log it while
+// debugging a proof. The label tells what data is: "protocol" for the
+// protocol name seeding the transcript, "message" for prover messages
+// stirred into the transcript and "challenge" for the encoding of public
+// randomness drawn from it. data is a copy that the observer may keep, and
+// observing does not change the proof nor the challenges.
+type TranscriptObserver func(label string, data []byte)
+
+// observe calls obs with a copy of data, if obs is not nil.
+func (obs TranscriptObserver) observe(label string, data []byte) {
+	if obs != nil {
+		obs(label, append([]byte{}, data...))
+	}
+}
+
+// observeChallenge calls obs with the encoding of the public randomness in
+// data, if obs is not nil.
+func (obs TranscriptObserver) observeChallenge(suite Suite, data []interface{}) error {
+	if obs == nil {
+		return nil
+	}
+	var b bytes.Buffer
+	if err := suite.Write(&b, data...); err != nil {
+		return err
+	}
+	obs("challenge", b.Bytes())
+	return nil
+}
+
+// XOFFunc creates the extendable-output function from which the challenges
+// of a noninteractive proof are derived, seeded with the protocol name.
+type XOFFunc func(seed []byte) kyber.XOF
+
+func newHashProver(suite Suite, newXOF XOFFunc, protoName string, obs TranscriptObserver) *hashProver {
 	var sc hashProver
 	sc.suite = suite
-	sc.pubrand = suite.XOF([]byte(protoName))
+	sc.obs = obs
+	sc.obs.observe("protocol", []byte(protoName))
+	sc.pubrand = newXOF([]byte(protoName))
 	sc.prirand = &cipherStreamReader{suite.RandomStream()}
 	return &sc
 }
@@ -48,6 +85,7 @@ func (c *hashProver) consumeMsg() {
 
 		// Stir the message into the public randomness pool
 		buf := c.msg.Bytes()
+		c.obs.observe("message", buf)
 		c.pubrand.Reseed()
 		c.pubrand.Write(buf)
 
@@ -60,7 +98,10 @@ func (c *hashProver) consumeMsg() {
 // Get public randomness that depends on every bit in the proof so far.
 func (c *hashProver) PubRand(data ...interface{}) error {
 	c.consumeMsg()
-	return c.suite.Read(c.pubrand, data...)
+	if err := c.suite.Read(c.pubrand, data...); err != nil {
+		return err
+	}
+	return c.obs.observeChallenge(c.suite, data)
 }
 
 // Get private randomness
@@ -83,17 +124,20 @@ type hashVerifier struct {
 	proof   bytes.Buffer // Buffer with which to read the proof
 	prbuf   []byte       // Byte-slice underlying proof buffer
 	pubrand kyber.XOF
+	obs     TranscriptObserver
 }
 
-func newHashVerifier(suite Suite, protoName string,
-	proof []byte) (*hashVerifier, error) {
+func newHashVerifier(suite Suite, newXOF XOFFunc, protoName string,
+	proof []byte, obs TranscriptObserver) (*hashVerifier, error) {
 	var c hashVerifier
 	if _, err := c.proof.Write(proof); err != nil {
 		return nil, err
 	}
 	c.suite = suite
+	c.obs = obs
+	c.obs.observe("protocol", []byte(protoName))
 	c.prbuf = c.proof.Bytes()
-	c.pubrand = suite.XOF([]byte(protoName))
+	c.pubrand = newXOF([]byte(protoName))
 	return &c, nil
 }
 
@@ -102,6 +146,7 @@ func (c *hashVerifier) consumeMsg() {
 	if l > 0 {
 		// Stir consumed bytes into the public randomness pool
 		buf := c.prbuf[:l]
+		c.obs.observe("message", buf)
 		c.pubrand.Reseed()
 		c.pubrand.Write(buf)
 
@@ -117,7 +162,10 @@ func (c *hashVerifier) Get(message interface{}) error {
 // Get public randomness that depends on every bit in the proof so far.
 func (c *hashVerifier) PubRand(data ...interface{}) error {
 	c.consumeMsg() // Stir in newly-read data
-	return c.suite.Read(c.pubrand, data...)
+	if err := c.suite.Read(c.pubrand, data...); err != nil {
+		return err
+	}
+	return c.obs.observeChallenge(c.suite, data)
 }
 
 // HashProve runs a given Sigma-protocol prover with a ProverContext
@@ -134,7 +182,28 @@ func (c *hashVerifier) PubRand(data ...interface{}) error {
 // pseudorandom stream based on a secret seed to create
 // deterministically reproducible proofs.
 func HashProve(suite Suite, protocolName string, prover Prover) ([]byte, error) {
-	ctx := newHashProver(suite, protocolName)
+	return HashProveWithXOF(suite, suite.XOF, protocolName, prover)
+}
+
+// HashProveWithXOF is like HashProve, but derives the challenges from the
+// XOF created by newXOF instead of the one of the suite, for instance
+// keccak.New for SHAKE256. The resulting proof only verifies with
+// HashVerifyWithXOF and the same newXOF.
+func HashProveWithXOF(suite Suite, newXOF XOFFunc, protocolName string,
+	prover Prover) ([]byte, error) {
+	return hashProve(suite, newXOF, protocolName, prover, nil)
+}
+
+// HashProveWithObserver is like HashProve, but calls obs with each element
+// of the Fiat-Shamir transcript. The proof is the same as without observer.
+func HashProveWithObserver(suite Suite, protocolName string, prover Prover,
+	obs TranscriptObserver) ([]byte, error) {
+	return hashProve(suite, suite.XOF, protocolName, prover, obs)
+}
+
+func hashProve(suite Suite, newXOF XOFFunc, protocolName string,
+	prover Prover, obs TranscriptObserver) ([]byte, error) {
+	ctx := newHashProver(suite, newXOF, protocolName, obs)
 	if e := (func(ProverContext) error)(prover)(ctx); e != nil {
 		return nil, e
 	}
@@ -146,7 +215,27 @@ func HashProve(suite Suite, protocolName string, prover Prover) ([]byte, error)
 // Returns nil if the proof checks out, or an error on any failure.
 func HashVerify(suite Suite, protocolName string,
 	verifier Verifier, proof []byte) error {
-	ctx, err := newHashVerifier(suite, protocolName, proof)
+	return HashVerifyWithXOF(suite, suite.XOF, protocolName, verifier, proof)
+}
+
+// HashVerifyWithXOF verifies a proof generated with HashProveWithXOF.
+// The suite, newXOF and protocolName must be the same as those given to
+// HashProveWithXOF.
+func HashVerifyWithXOF(suite Suite, newXOF XOFFunc, protocolName string,
+	verifier Verifier, proof []byte) error {
+	return hashVerify(suite, newXOF, protocolName, verifier, proof, nil)
+}
+
+// HashVerifyWithObserver is like HashVerify, but calls obs with each element
+// of the Fiat-Shamir transcript.
+func HashVerifyWithObserver(suite Suite, protocolName string,
+	verifier Verifier, proof []byte, obs TranscriptObserver) error {
+	return hashVerify(suite, suite.XOF, protocolName, verifier, proof, obs)
+}
+
+func hashVerify(suite Suite, newXOF XOFFunc, protocolName string,
+	verifier Verifier, proof []byte, obs TranscriptObserver) error {
+	ctx, err := newHashVerifier(suite, newXOF, protocolName, proof, obs)
 	if err != nil {
 		return err
 	}
@@ -3,10 +3,13 @@ package proof
 import (
 	"encoding/hex"
 	"fmt"
+	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/group/edwards25519"
 	"go.dedis.ch/kyber/v3/xof/blake2xb"
+	"go.dedis.ch/kyber/v3/xof/keccak"
 )
 
 // This example shows how to build classic ElGamal-style digital signatures
@@ -165,3 +168,85 @@ func Example_hashProve2() {
 	// 00000170  70 b8 35 6c fe 03 1f b0  08 42 e0 5d b2 5e 40 04  |p.5l.....B.].^@.|
 	// Linkable Ring Signature verified.
 }
+
+func TestHashProveWithXOF(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	x := suite.Scalar().Pick(suite.RandomStream())
+	pub := map[string]kyber.Point{
+		"B": suite.Point().Base(),
+		"X": suite.Point().Mul(x, nil),
+	}
+	rep := Rep("X", "x", "B")
+	prover := rep.Prover(suite, map[string]kyber.Scalar{"x": x}, pub, nil)
+	proof, err := HashProveWithXOF(suite, keccak.New, "test", prover)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = HashVerifyWithXOF(suite, keccak.New, "test", rep.Verifier(suite, pub), proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The suite uses blake2xb, so the default verifier must reject the proof.
+	if HashVerify(suite, "test", rep.Verifier(suite, pub), proof) == nil {
+		t.Fatal("proof verified with a different hash")
+	}
+	err = HashVerifyWithXOF(suite, blake2xb.New, "test", rep.Verifier(suite, pub), proof)
+	if err == nil {
+		t.Fatal("proof verified with a different hash")
+	}
+}
+
+func TestHashProveWithObserver(t *testing.T) {
+	newSuite := func() Suite {
+		return edwards25519.NewBlakeSHA256Ed25519WithRand(blake2xb.New([]byte("observer")))
+	}
+	suite := newSuite()
+	x := suite.Scalar().Pick(suite.RandomStream())
+	B := suite.Point().Base()
+	H := suite.Point().Pick(suite.RandomStream())
+	pub := map[string]kyber.Point{
+		"B": B, "H": H,
+		"X": suite.Point().Mul(x, B),
+		"Y": suite.Point().Mul(x, H),
+	}
+	sec := map[string]kyber.Scalar{"x": x}
+	dleq := And(Rep("X", "x", "B"), Rep("Y", "x", "H"))
+
+	type element struct {
+		label string
+		data  []byte
+	}
+	var proverSeq, verifierSeq []element
+	observer := func(seq *[]element) TranscriptObserver {
+		return func(label string, data []byte) {
+			*seq = append(*seq, element{label, data})
+		}
+	}
+
+	proof, err := HashProveWithObserver(suite, "DLEQ", dleq.Prover(suite, sec, pub, nil), observer(&proverSeq))
+	require.NoError(t, err)
+	err = HashVerifyWithObserver(suite, "DLEQ", dleq.Verifier(suite, pub), proof, observer(&verifierSeq))
+	require.NoError(t, err)
+
+	// the prover commits, gets the challenge and responds, and the verifier
+	// does not stir the last response since it draws no challenge after it
+	var labels []string
+	for _, e := range proverSeq {
+		labels = append(labels, e.label)
+	}
+	require.Equal(t, []string{"protocol", "message", "challenge", "message"}, labels)
+	require.Equal(t, proverSeq[:3], verifierSeq)
+	require.Equal(t, []byte("DLEQ"), proverSeq[0].data)
+	require.Len(t, proverSeq[1].data, 2*suite.PointLen())
+	require.Len(t, proverSeq[2].data, suite.ScalarLen())
+	require.Equal(t, proof, append(proverSeq[1].data, proverSeq[3].data...))
+
+	// observing does not change the proof
+	suite2 := newSuite()
+	suite2.Scalar().Pick(suite2.RandomStream())
+	suite2.Point().Pick(suite2.RandomStream())
+	proof2, err := HashProve(suite2, "DLEQ", dleq.Prover(suite2, sec, pub, nil))
+	require.NoError(t, err)
+	require.Equal(t, proof, proof2)
+}
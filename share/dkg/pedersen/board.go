@@ -0,0 +1,125 @@
+package dkg
+
+import (
+	"errors"
+	"sync"
+)
+
+// Board is the means by which nodes exchange the messages of the DKG, for
+// example a network layer or a public bulletin board. Messages received by
+// the node are pushed to the board, usually from several goroutines, and the
+// protocol reads them from the incoming channels.
+type Board interface {
+	PushDeal(d *Deal) error
+	PushResponse(r *Response) error
+	PushJustification(j *Justification) error
+	IncomingDeal() <-chan *Deal
+	IncomingResponse() <-chan *Response
+	IncomingJustification() <-chan *Justification
+}
+
+// ErrBoardClosed is returned when a message is pushed to a closed board.
+var ErrBoardClosed = errors.New("dkg: board closed")
+
+// SafeBoard is a Board whose Push methods can be called concurrently, also
+// with Close. A push blocks while the channel of its message type is full.
+type SafeBoard struct {
+	m      sync.RWMutex
+	once   sync.Once
+	done   chan struct{}
+	closed bool
+	deals  chan *Deal
+	resps  chan *Response
+	justs  chan *Justification
+}
+
+var _ Board = (*SafeBoard)(nil)
+
+// NewSafeBoard returns a SafeBoard whose incoming channels can each hold
+// buffer messages not yet read.
+func NewSafeBoard(buffer int) *SafeBoard {
+	return &SafeBoard{
+		done:  make(chan struct{}),
+		deals: make(chan *Deal, buffer),
+		resps: make(chan *Response, buffer),
+		justs: make(chan *Justification, buffer),
+	}
+}
+
+// PushDeal adds a deal to the board. It returns ErrBoardClosed if the board
+// is closed, also while it waits for room in the channel.
+func (b *SafeBoard) PushDeal(d *Deal) error {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	if b.closed {
+		return ErrBoardClosed
+	}
+	select {
+	case b.deals <- d:
+		return nil
+	case <-b.done:
+		return ErrBoardClosed
+	}
+}
+
+// PushResponse adds a response to the board, see PushDeal.
+func (b *SafeBoard) PushResponse(r *Response) error {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	if b.closed {
+		return ErrBoardClosed
+	}
+	select {
+	case b.resps <- r:
+		return nil
+	case <-b.done:
+		return ErrBoardClosed
+	}
+}
+
+// PushJustification adds a justification to the board, see PushDeal.
+func (b *SafeBoard) PushJustification(j *Justification) error {
+	b.m.RLock()
+	defer b.m.RUnlock()
+	if b.closed {
+		return ErrBoardClosed
+	}
+	select {
+	case b.justs <- j:
+		return nil
+	case <-b.done:
+		return ErrBoardClosed
+	}
+}
+
+// IncomingDeal returns the channel of the deals pushed to the board.
+func (b *SafeBoard) IncomingDeal() <-chan *Deal {
+	return b.deals
+}
+
+// IncomingResponse returns the channel of the responses pushed to the board.
+func (b *SafeBoard) IncomingResponse() <-chan *Response {
+	return b.resps
+}
+
+// IncomingJustification returns the channel of the justifications pushed to
+// the board.
+func (b *SafeBoard) IncomingJustification() <-chan *Justification {
+	return b.justs
+}
+
+// Close signals that no more messages will be pushed: the pending pushes
+// fail and the incoming channels are closed once the messages already in
+// them are read. It can be called several times.
+func (b *SafeBoard) Close() {
+	b.once.Do(func() {
+		close(b.done)
+		// wait for the pushes in progress to return
+		b.m.Lock()
+		defer b.m.Unlock()
+		b.closed = true
+		close(b.deals)
+		close(b.resps)
+		close(b.justs)
+	})
+}
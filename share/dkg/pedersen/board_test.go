@@ -0,0 +1,104 @@
+package dkg
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+func TestSafeBoardConcurrent(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	n := len(dkgs)
+	// small buffers so that pushes wait for the protocol to read
+	boards := make([]*SafeBoard, n)
+	for i := range boards {
+		boards[i] = NewSafeBoard(2)
+	}
+
+	// the goroutines report their errors to the test goroutine, which alone
+	// may fail the test
+	errs := make(chan error, 2*n*n*n)
+	var pushers sync.WaitGroup
+	for _, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		for i, d := range deals {
+			pushers.Add(1)
+			go func(b *SafeBoard, d *Deal) {
+				defer pushers.Done()
+				errs <- b.PushDeal(d)
+			}(boards[i], d)
+		}
+	}
+
+	// every node reads its deals, broadcasts its responses, then reads the
+	// responses of the others
+	var readers sync.WaitGroup
+	for i, dkg := range dkgs {
+		readers.Add(1)
+		go func(i int, dkg *DistKeyGenerator) {
+			defer readers.Done()
+			for k := 0; k < n-1; k++ {
+				resp, err := dkg.ProcessDeal(<-boards[i].IncomingDeal())
+				if err == nil && resp.Response.Status != vss.StatusApproval {
+					err = errors.New("unexpected complaint")
+				}
+				if err != nil {
+					errs <- err
+					continue
+				}
+				for j, b := range boards {
+					if j == i {
+						continue
+					}
+					pushers.Add(1)
+					go func(b *SafeBoard) {
+						defer pushers.Done()
+						errs <- b.PushResponse(resp)
+					}(b)
+				}
+			}
+			for k := 0; k < n*(n-1)-(n-1); k++ {
+				j, err := dkg.ProcessResponse(<-boards[i].IncomingResponse())
+				if err == nil && j != nil {
+					err = errors.New("unexpected justification")
+				}
+				errs <- err
+			}
+		}(i, dkg)
+	}
+	readers.Wait()
+	pushers.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	for i, dkg := range dkgs {
+		require.True(t, dkg.Certified())
+		boards[i].Close()
+		boards[i].Close()
+		require.Equal(t, ErrBoardClosed, boards[i].PushJustification(&Justification{}))
+		_, ok := <-boards[i].IncomingJustification()
+		require.False(t, ok)
+	}
+}
+
+func TestSafeBoardCloseUnblocksPush(t *testing.T) {
+	b := NewSafeBoard(1)
+	require.NoError(t, b.PushDeal(&Deal{}))
+	errs := make(chan error)
+	go func() {
+		errs <- b.PushDeal(&Deal{})
+	}()
+	b.Close()
+	require.Equal(t, ErrBoardClosed, <-errs)
+	// the buffered deal can still be read
+	_, ok := <-b.IncomingDeal()
+	require.True(t, ok)
+	_, ok = <-b.IncomingDeal()
+	require.False(t, ok)
+}
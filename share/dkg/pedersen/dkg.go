@@ -11,13 +11,20 @@
 package dkg
 
 import (
+	"bytes"
+	"crypto/cipher"
 	"crypto/rand"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
+	"runtime"
+	"sort"
+	"sync"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/util/random"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
 
 	"go.dedis.ch/kyber/v3/share"
 	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
@@ -46,7 +53,8 @@ type Config struct {
 	// will have invalid shares after the protocol has been run. To be able to issue
 	// new shares to a new group, the group member's public key must be inside this
 	// list and in the Share field. Keys can be disjoint or not with respect to the
-	// NewNodes list.
+	// NewNodes list. The index of a node is its position in this list, which
+	// all nodes must agree on: build it with SortNodes.
 	OldNodes []kyber.Point
 
 	// PublicCoeffs are the coefficients of the distributed polynomial needed
@@ -57,7 +65,8 @@ type Config struct {
 	// Expected new group of share holders. These public-key designated nodes
 	// will be in possession of new shares after the protocol has been run. To be a
 	// receiver of a new share, one's public key must be inside this list. Keys
-	// can be disjoint or not with respect to the OldNodes list.
+	// can be disjoint or not with respect to the OldNodes list. Like OldNodes,
+	// it should be built with SortNodes.
 	NewNodes []kyber.Point
 
 	// Share to refresh. It must be nil for a new node wishing to
@@ -91,7 +100,84 @@ type Config struct {
 
 	// When UserReaderOnly it set to true, only the user-specified entropy source
 	// Reader will be used. This should only be used in tests, allowing reproducibility.
+	// A seed read from Reader then also replaces the random stream of the
+	// suite for all the randomness of the DistKeyGenerator: its secret and the
+	// other coefficients of its polynomial, the ephemeral keys encrypting its
+	// deals and the nonces of its signatures. Running the same calls in the
+	// same order with readers returning the same bytes reproduces every message
+	// bit for bit; this does not hold for ProcessDeals, which signs responses
+	// concurrently.
 	UserReaderOnly bool
+
+	// MinResponses is the number of responses, counted over all the deals
+	// received, that the DistKeyGenerator must have processed before
+	// DistKeyShare computes the distributed key. Until then DistKeyShare
+	// returns ErrNotEnoughResponses, so that the caller can wait for more
+	// responses and retry instead of calling SetTimeout too early. A node's
+	// own responses count, so that a full exchange between n nodes gives
+	// each of them n*n responses. If it is zero, there is no minimum.
+	MinResponses int
+
+	// RotatedKeys registers, by index in the list of dealers, public keys
+	// under which the signature of a deal is accepted in addition to the key
+	// in the list, for example the new key of a dealer during a key rotation
+	// while its old key is still listed. The list of dealers is OldNodes when
+	// resharing and NewNodes otherwise. Only the signature of the Deal is
+	// concerned: the deal it holds is still encrypted and signed by the
+	// dealer with the key in the list.
+	RotatedKeys map[uint32][]kyber.Point
+
+	// Nonce is an optional value that all the nodes agree on, such as a
+	// counter or a random value drawn by a coordinator, which distinguishes
+	// runs of the DKG between the same nodes with the same threshold. It is
+	// bound to the deals through SessionID.
+	Nonce []byte
+}
+
+// SessionID returns an identifier of the DKG described by c: the hash of
+// the public keys of the old and new nodes, each list sorted by the binary
+// encoding of the keys, of the threshold, of OldThreshold when resharing and
+// of Nonce. Every deal carries it under the signature of its dealer, and
+// ProcessDeal rejects the deals of another session, so that a deal cannot
+// be replayed in a run with other parameters. For a fresh DKG, where
+// OldNodes is empty, the old nodes are the new ones. An error is returned if
+// a key cannot be marshalled.
+func (c *Config) SessionID() ([]byte, error) {
+	h := c.Suite.Hash()
+	_, _ = h.Write([]byte("dkg session"))
+	oldNodes := c.OldNodes
+	if len(oldNodes) == 0 {
+		oldNodes = c.NewNodes
+	}
+	for _, nodes := range [][]kyber.Point{oldNodes, c.NewNodes} {
+		bufs := make([][]byte, len(nodes))
+		for i, n := range nodes {
+			buf, err := n.MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			bufs[i] = buf
+		}
+		sort.Slice(bufs, func(i, j int) bool {
+			return bytes.Compare(bufs[i], bufs[j]) < 0
+		})
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(bufs)))
+		for _, buf := range bufs {
+			_, _ = h.Write(buf)
+		}
+	}
+	t := c.Threshold
+	if t == 0 {
+		t = vss.MinimumT(len(c.NewNodes))
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(t))
+	// OldThreshold is ignored by a fresh DKG
+	if c.Share != nil || c.PublicCoeffs != nil {
+		_ = binary.Write(h, binary.LittleEndian, uint32(c.OldThreshold))
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(c.Nonce)))
+	_, _ = h.Write(c.Nonce)
+	return h.Sum(nil), nil
 }
 
 // DistKeyGenerator is the struct that runs the DKG protocol.
@@ -130,8 +216,26 @@ type DistKeyGenerator struct {
 	processed bool
 	// did the timeout / period / already occured or not
 	timeout bool
+	// justifications accepted, indexed by dealer index
+	justifications map[uint32][]*vss.Justification
+	// session ID of the config, carried by the deals
+	sid []byte
 }
 
+// ErrDuplicateNode is returned when a node list holds the same public key
+// twice.
+var ErrDuplicateNode = errors.New("dkg: duplicate node")
+
+// ErrNotEnoughResponses is returned by DistKeyShare when fewer than
+// Config.MinResponses responses have been processed.
+var ErrNotEnoughResponses = errors.New("dkg: not enough responses")
+
+// MaxNodes is the largest number of nodes in the old or the new list of a
+// Config. Node indices then fit on 16 bits, as needed by the index of
+// threshold BLS signature shares (see sign/tbls), and the quadratic number
+// of deals and responses stays bounded.
+const MaxNodes = 1 << 16
+
 // NewDistKeyHandler takes a Config and returns a DistKeyGenerator that is able
 // to drive the DKG or resharing protocol.
 func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
@@ -139,6 +243,19 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 		return nil, errors.New("dkg: can't run with empty node list")
 	}
 
+	if err := checkMaxNodes(c.OldNodes, "OldNodes"); err != nil {
+		return nil, err
+	}
+	if err := checkMaxNodes(c.NewNodes, "NewNodes"); err != nil {
+		return nil, err
+	}
+	if err := checkDuplicates(c.OldNodes, "OldNodes"); err != nil {
+		return nil, err
+	}
+	if err := checkDuplicates(c.NewNodes, "NewNodes"); err != nil {
+		return nil, err
+	}
+
 	var isResharing bool
 	if c.Share != nil || c.PublicCoeffs != nil {
 		isResharing = true
@@ -151,6 +268,15 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 			return nil, errors.New("dkg: resharing case needs old threshold field")
 		}
 	}
+	var suite Suite
+	if c.Reader != nil && c.UserReaderOnly {
+		var seed [32]byte
+		random.Bytes(seed[:], random.New(c.Reader))
+		suite = &readerSuite{Suite: c.Suite, stream: blake2xb.New(seed[:])}
+	} else {
+		suite = &lockedSuite{Suite: c.Suite}
+	}
+
 	// canReceive is true by default since in the default DKG mode everyone
 	// participates
 	var canReceive = true
@@ -174,7 +300,7 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 	if c.Share != nil {
 		// resharing case
 		secretCoeff := c.Share.Share.V
-		dealer, err = vss.NewDealer(c.Suite, c.Longterm, secretCoeff, c.NewNodes, newThreshold)
+		dealer, err = vss.NewDealer(suite, c.Longterm, secretCoeff, c.NewNodes, newThreshold)
 		canIssue = true
 	} else if !isResharing && newPresent {
 		// fresh DKG case
@@ -183,10 +309,10 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 		if c.Reader != nil && !c.UserReaderOnly {
 			randomStream = random.New(c.Reader, rand.Reader)
 		} else if c.Reader != nil && c.UserReaderOnly {
-			randomStream = random.New(c.Reader)
+			randomStream = suite.RandomStream()
 		}
 		secretCoeff := c.Suite.Scalar().Pick(randomStream)
-		dealer, err = vss.NewDealer(c.Suite, c.Longterm, secretCoeff, c.NewNodes, newThreshold)
+		dealer, err = vss.NewDealer(suite, c.Longterm, secretCoeff, c.NewNodes, newThreshold)
 		canIssue = true
 		c.OldNodes = c.NewNodes
 		oidx, oldPresent = findPub(c.OldNodes, pub)
@@ -217,10 +343,14 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 		canReceive = true
 		oldThreshold = len(c.PublicCoeffs)
 	}
+	sid, err := c.SessionID()
+	if err != nil {
+		return nil, err
+	}
 	dkg := &DistKeyGenerator{
 		dealer:         dealer,
 		oldAggregators: make(map[uint32]*vss.Aggregator),
-		suite:          c.Suite,
+		suite:          suite,
 		long:           c.Longterm,
 		pub:            pub,
 		canReceive:     canReceive,
@@ -234,6 +364,7 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 		newT:           newThreshold,
 		newPresent:     newPresent,
 		oldPresent:     oldPresent,
+		sid:            sid,
 	}
 	if newPresent {
 		err = dkg.initVerifiers(c)
@@ -241,6 +372,49 @@ func NewDistKeyHandler(c *Config) (*DistKeyGenerator, error) {
 	return dkg, err
 }
 
+// readerSuite replaces the random stream of a suite by the one built from
+// Config.Reader when Config.UserReaderOnly is set.
+type readerSuite struct {
+	Suite
+	m      sync.Mutex
+	stream cipher.Stream
+}
+
+func (s *readerSuite) RandomStream() cipher.Stream {
+	return s
+}
+
+// XORKeyStream serializes the reads from the stream, whose reader may not be
+// safe for concurrent use.
+func (s *readerSuite) XORKeyStream(dst, src []byte) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.stream.XORKeyStream(dst, src)
+}
+
+// lockedSuite serializes the reads from the random streams of a suite, which
+// may all be the same stream, as for the suites created with a given
+// stream, since ProcessDeals signs responses concurrently.
+type lockedSuite struct {
+	Suite
+	m sync.Mutex
+}
+
+func (s *lockedSuite) RandomStream() cipher.Stream {
+	return &lockedStream{m: &s.m, stream: s.Suite.RandomStream()}
+}
+
+type lockedStream struct {
+	m      *sync.Mutex
+	stream cipher.Stream
+}
+
+func (l *lockedStream) XORKeyStream(dst, src []byte) {
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.stream.XORKeyStream(dst, src)
+}
+
 // NewDistKeyGenerator returns a dist key generator ready to create a fresh
 // distributed key with the regular DKG protocol.
 func NewDistKeyGenerator(suite Suite, longterm kyber.Scalar, participants []kyber.Point, t int) (*DistKeyGenerator, error) {
@@ -279,8 +453,9 @@ func (d *DistKeyGenerator) Deals() (map[int]*Deal, error) {
 	dd := make(map[int]*Deal)
 	for i := range d.c.NewNodes {
 		distd := &Deal{
-			Index: uint32(d.oidx),
-			Deal:  deals[i],
+			Index:     uint32(d.oidx),
+			SessionID: d.sid,
+			Deal:      deals[i],
 		}
 		// sign the deal
 		buff, err := distd.MarshalBinary()
@@ -331,13 +506,16 @@ func (d *DistKeyGenerator) ProcessDeal(dd *Deal) (*Response, error) {
 	if !ok {
 		return nil, errors.New("dkg: dist deal out of bounds index")
 	}
+	if !bytes.Equal(dd.SessionID, d.sid) {
+		return nil, errors.New("dkg: deal from another session")
+	}
 
 	// verify signature
 	buff, err := dd.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
-	if err := schnorr.Verify(d.suite, pub, buff, dd.Signature); err != nil {
+	if err := d.verifySignature(pub, dd.Index, buff, dd.Signature); err != nil {
 		return nil, err
 	}
 
@@ -401,6 +579,71 @@ func (d *DistKeyGenerator) ProcessDeal(dd *Deal) (*Response, error) {
 	}, nil
 }
 
+// verifySignature checks that sig is a signature of msg by the dealer at the
+// given index, whose public key in the list is pub, under pub or under one of
+// its keys in Config.RotatedKeys.
+func (d *DistKeyGenerator) verifySignature(pub kyber.Point, index uint32, msg, sig []byte) error {
+	err := schnorr.Verify(d.suite, pub, msg, sig)
+	if err == nil {
+		return nil
+	}
+	for _, k := range d.c.RotatedKeys[index] {
+		if schnorr.Verify(d.suite, k, msg, sig) == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+// ProcessDeals processes a batch of deals as successive calls to ProcessDeal
+// would, but verifies the deals of distinct dealers in parallel on at most
+// GOMAXPROCS goroutines. The response and the error for deals[i] are
+// returned at index i of the two returned slices, so the outcome does not
+// depend on the scheduling. Deals coming from the same dealer are processed
+// one after the other, in the order they are given.
+func (d *DistKeyGenerator) ProcessDeals(deals []*Deal) ([]*Response, []error) {
+	resps := make([]*Response, len(deals))
+	errs := make([]error, len(deals))
+
+	// each dealer has its own verifier, the only state ProcessDeal modifies
+	var dealers []uint32
+	byDealer := make(map[uint32][]int)
+	for i, dd := range deals {
+		if dd == nil {
+			errs[i] = errors.New("dkg: nil deal")
+			continue
+		}
+		if _, ok := byDealer[dd.Index]; !ok {
+			dealers = append(dealers, dd.Index)
+		}
+		byDealer[dd.Index] = append(byDealer[dd.Index], i)
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(dealers) {
+		workers = len(dealers)
+	}
+	jobs := make(chan uint32)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for idx := range jobs {
+				for _, i := range byDealer[idx] {
+					resps[i], errs[i] = d.ProcessDeal(deals[i])
+				}
+			}
+		}()
+	}
+	for _, idx := range dealers {
+		jobs <- idx
+	}
+	close(jobs)
+	wg.Wait()
+	return resps, errs
+}
+
 // ProcessResponse takes a response from every other peer.  If the response
 // designates the deal of another participant than this dkg, this dkg stores it
 // and returns nil with a possible error regarding the validity of the response.
@@ -485,7 +728,43 @@ func (d *DistKeyGenerator) ProcessJustification(j *Justification) error {
 	if !ok {
 		return errors.New("dkg: Justification received but no deal for it")
 	}
-	return v.ProcessJustification(j.Justification)
+	if err := v.ProcessJustification(j.Justification); err != nil {
+		return err
+	}
+	if d.justifications == nil {
+		d.justifications = make(map[uint32][]*vss.Justification)
+	}
+	d.justifications[j.Index] = append(d.justifications[j.Index], j.Justification)
+	return nil
+}
+
+// ProcessJustifications processes a batch of justifications as successive
+// calls to ProcessJustification would, in a canonical order that does not
+// depend on the order of arrival: by increasing index of the dealer, then of
+// the verifier who complained. Processing the same justifications thus
+// always leads to the same state, and the same QUAL set. The error for
+// justs[i] is returned at index i.
+func (d *DistKeyGenerator) ProcessJustifications(justs []*Justification) []error {
+	errs := make([]error, len(justs))
+	order := make([]int, 0, len(justs))
+	for i, j := range justs {
+		if j == nil || j.Justification == nil {
+			errs[i] = errors.New("dkg: nil justification")
+			continue
+		}
+		order = append(order, i)
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		ja, jb := justs[order[a]], justs[order[b]]
+		if ja.Index != jb.Index {
+			return ja.Index < jb.Index
+		}
+		return ja.Justification.Index < jb.Justification.Index
+	})
+	for _, i := range order {
+		errs[i] = d.ProcessJustification(justs[i])
+	}
+	return errs
 }
 
 // SetTimeout triggers the timeout on all verifiers, and thus makes sure
@@ -603,6 +882,13 @@ func (d *DistKeyGenerator) QualifiedShares() []int {
 	return validHolders
 }
 
+// CanIssue returns true if this node is a dealer, i.e. if it holds a share
+// of the secret (or is part of a fresh DKG) and Deals can be called. A node
+// that only joins the new group during a resharing can not issue deals.
+func (d *DistKeyGenerator) CanIssue() bool {
+	return d.canIssue
+}
+
 // ExpectedDeals returns the number of deals that this node will
 // receive from the other participants.
 func (d *DistKeyGenerator) ExpectedDeals() int {
@@ -620,6 +906,10 @@ func (d *DistKeyGenerator) ExpectedDeals() int {
 // set, i.e. the list of Certified deals.
 // It does NOT take into account any malicious share holder which share may have
 // been revealed, due to invalid complaint.
+// The indices are always returned in ascending order: whether a deal belongs to
+// QUAL only depends on the responses and justifications received for it, so
+// two honest nodes that processed the same messages return the exact same
+// slice, regardless of the order in which those messages arrived.
 func (d *DistKeyGenerator) QUAL() []int {
 	var good []int
 	if d.isResharing && d.canIssue && !d.newPresent {
@@ -648,8 +938,16 @@ func (d *DistKeyGenerator) isInQUAL(idx uint32) bool {
 	return found
 }
 
+// qualIter calls fn on every certified deal by ascending dealer index, until
+// fn returns false.
 func (d *DistKeyGenerator) qualIter(fn func(idx uint32, v *vss.Verifier) bool) {
-	for i, v := range d.verifiers {
+	indices := make([]uint32, 0, len(d.verifiers))
+	for i := range d.verifiers {
+		indices = append(indices, i)
+	}
+	sortIndices(indices)
+	for _, i := range indices {
+		v := d.verifiers[i]
 		if v.DealCertified() {
 			if !fn(i, v) {
 				break
@@ -658,8 +956,16 @@ func (d *DistKeyGenerator) qualIter(fn func(idx uint32, v *vss.Verifier) bool) {
 	}
 }
 
+// oldQualIter is the equivalent of qualIter for the aggregators of a node
+// leaving the group.
 func (d *DistKeyGenerator) oldQualIter(fn func(idx uint32, v *vss.Aggregator) bool) {
-	for i, v := range d.oldAggregators {
+	indices := make([]uint32, 0, len(d.oldAggregators))
+	for i := range d.oldAggregators {
+		indices = append(indices, i)
+	}
+	sortIndices(indices)
+	for _, i := range indices {
+		v := d.oldAggregators[i]
 		if v.DealCertified() {
 			if !fn(i, v) {
 				break
@@ -676,6 +982,9 @@ func (d *DistKeyGenerator) oldQualIter(fn func(idx uint32, v *vss.Aggregator) bo
 // The share is evaluated from the global Private Polynomial, basically SUM of
 // fj(i) for a receiver i.
 func (d *DistKeyGenerator) DistKeyShare() (*DistKeyShare, error) {
+	if d.receivedResponses() < d.c.MinResponses {
+		return nil, ErrNotEnoughResponses
+	}
 	if !d.ThresholdCertified() {
 		return nil, errors.New("dkg: distributed key not certified")
 	}
@@ -690,6 +999,16 @@ func (d *DistKeyGenerator) DistKeyShare() (*DistKeyShare, error) {
 	return d.dkgKey()
 }
 
+// receivedResponses returns the number of responses stored by the verifiers
+// of this generator.
+func (d *DistKeyGenerator) receivedResponses() int {
+	var n int
+	for _, v := range d.verifiers {
+		n += len(v.Responses())
+	}
+	return n
+}
+
 func (d *DistKeyGenerator) dkgKey() (*DistKeyShare, error) {
 	sh := d.suite.Scalar().Zero()
 	var pub *share.PubPoly
@@ -803,7 +1122,7 @@ func (d *DistKeyGenerator) initVerifiers(c *Config) error {
 			return errors.New("duplicate public key in NewNodes list")
 		}
 		alreadyTaken[pub.String()] = true
-		ver, err := vss.NewVerifier(c.Suite, c.Longterm, pub, verifierList)
+		ver, err := vss.NewVerifier(d.suite, c.Longterm, pub, verifierList)
 		if err != nil {
 			return err
 		}
@@ -842,6 +1161,149 @@ func (d *DistKeyShare) Renew(suite Suite, g *DistKeyShare) (*DistKeyShare, error
 	}, nil
 }
 
+// VerifyDealShare checks a single deal in isolation, without any
+// DistKeyGenerator: it verifies the signature of the dealer, decrypts the
+// deal with the private key of its recipient and checks the share against
+// the commitments of the dealer. dealers is the list of nodes issuing deals
+// and recipients the list of nodes receiving them; both are the same list for
+// a fresh DKG. sid is the expected session ID, as returned by
+// Config.SessionID, so that a deal of another session is rejected. It
+// returns nil if the share is valid.
+func VerifyDealShare(suite Suite, dealers, recipients []kyber.Point, sid []byte, dd *Deal, recipientPriv kyber.Scalar) error {
+	pub, ok := getPub(dealers, dd.Index)
+	if !ok {
+		return errors.New("dkg: dist deal out of bounds index")
+	}
+	if !bytes.Equal(dd.SessionID, sid) {
+		return errors.New("dkg: deal from another session")
+	}
+	buff, err := dd.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if err := schnorr.Verify(suite, pub, buff, dd.Signature); err != nil {
+		return err
+	}
+	ver, err := vss.NewVerifier(suite, recipientPriv, pub, recipients)
+	if err != nil {
+		return err
+	}
+	deal, err := ver.DecryptDeal(dd.Deal)
+	if err != nil {
+		return err
+	}
+	if deal.SecShare.I != ver.Index() {
+		return errors.New("dkg: deal is not destined to this recipient")
+	}
+	return ver.VerifyDeal(deal, true)
+}
+
+// BatchVerifyDeals checks at once that the shares of the given decrypted
+// deals, all destined to the share holder at the given index, verify against
+// the commitments of their respective dealer. Instead of checking
+// g^s_j == sum_k C_jk * i^k for each deal j, it checks a random linear
+// combination of those equations, which costs a single polynomial evaluation.
+// If it returns an error, at least one of the deals is invalid and the deals
+// must be verified one by one to find which.
+func BatchVerifyDeals(suite Suite, deals []*vss.Deal, index int) error {
+	if len(deals) == 0 {
+		return nil
+	}
+	sum := suite.Scalar().Zero()
+	var commits []kyber.Point
+	for _, deal := range deals {
+		if deal.SecShare == nil || deal.SecShare.I != index {
+			return errors.New("dkg: deal is not destined to this index")
+		}
+		r := suite.Scalar().Pick(suite.RandomStream())
+		sum.Add(sum, suite.Scalar().Mul(r, deal.SecShare.V))
+		for k, c := range deal.Commitments {
+			if k == len(commits) {
+				commits = append(commits, suite.Point().Null())
+			}
+			commits[k].Add(commits[k], suite.Point().Mul(r, c))
+		}
+	}
+	expected := share.NewPubPoly(suite, nil, commits).Eval(index)
+	if !suite.Point().Mul(sum, nil).Equal(expected.V) {
+		return errors.New("dkg: batch of deals does not verify against the commitments")
+	}
+	return nil
+}
+
+// VerifyReshareConsistency checks a share obtained from a resharing against
+// both public polynomials involved: the new share must lie on the new public
+// polynomial, and the new polynomial must keep the distributed public key,
+// i.e. the constant term, of the old one.
+func VerifyReshareConsistency(oldPub, newPub *share.PubPoly, newShare *share.PriShare) error {
+	if oldPub == nil || newPub == nil || newShare == nil {
+		return errors.New("dkg: missing polynomial or share")
+	}
+	if !newPub.Check(newShare) {
+		return errors.New("dkg: share does not lie on the new public polynomial")
+	}
+	if !oldPub.Commit().Equal(newPub.Commit()) {
+		return errors.New("dkg: resharing changed the distributed public key")
+	}
+	return nil
+}
+
+// SortNodes returns a copy of nodes sorted by the binary encoding of the
+// keys. Since the index of a node is its position in Config.OldNodes or
+// Config.NewNodes, parties building these lists with SortNodes derive the
+// same indices from the same set of keys, in whatever order they learnt
+// them. An error is returned if a key appears twice.
+func SortNodes(nodes []kyber.Point) ([]kyber.Point, error) {
+	type node struct {
+		pub kyber.Point
+		buf []byte
+	}
+	sorted := make([]node, len(nodes))
+	for i, pub := range nodes {
+		buf, err := pub.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		sorted[i] = node{pub, buf}
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i].buf, sorted[j].buf) < 0
+	})
+	list := make([]kyber.Point, len(sorted))
+	for i, n := range sorted {
+		if i > 0 && bytes.Equal(n.buf, sorted[i-1].buf) {
+			return nil, fmt.Errorf("%w %s", ErrDuplicateNode, n.pub)
+		}
+		list[i] = n.pub
+	}
+	return list, nil
+}
+
+// checkMaxNodes returns an error if list holds more than MaxNodes keys.
+func checkMaxNodes(list []kyber.Point, name string) error {
+	if len(list) > MaxNodes {
+		return fmt.Errorf("dkg: %d nodes in %s, more than the maximum of %d", len(list), name, MaxNodes)
+	}
+	return nil
+}
+
+// checkDuplicates returns an error wrapping ErrDuplicateNode, with the
+// indices of the first repeated key, if list holds a key twice.
+func checkDuplicates(list []kyber.Point, name string) error {
+	seen := make(map[string]int, len(list))
+	for i, p := range list {
+		buf, err := p.MarshalBinary()
+		if err != nil {
+			return err
+		}
+		if j, ok := seen[string(buf)]; ok {
+			return fmt.Errorf("%w %s at indices %d and %d of %s", ErrDuplicateNode, p, j, i, name)
+		}
+		seen[string(buf)] = i
+	}
+	return nil
+}
+
 func getPub(list []kyber.Point, i uint32) (kyber.Point, bool) {
 	if i >= uint32(len(list)) {
 		return nil, false
@@ -858,6 +1320,10 @@ func findPub(list []kyber.Point, toFind kyber.Point) (int, bool) {
 	return 0, false
 }
 
+func sortIndices(indices []uint32) {
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+}
+
 func checksDealCertified(i uint32, v *vss.Verifier) bool {
 	return v.DealCertified()
 }
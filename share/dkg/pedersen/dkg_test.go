@@ -2,8 +2,10 @@ package dkg
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	mathRand "math/rand"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -12,6 +14,8 @@ import (
 	"go.dedis.ch/kyber/v3/group/edwards25519"
 	"go.dedis.ch/kyber/v3/share"
 	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
 )
 
 // Note: if you are looking for a complete scenario that shows DKG in action
@@ -138,6 +142,112 @@ func TestDKGProcessDeal(t *testing.T) {
 
 }
 
+// dealsFor returns the deals of every other generator destined to the
+// participant at index idx.
+func dealsFor(t testing.TB, dkgs []*DistKeyGenerator, idx int) []*Deal {
+	var deals []*Deal
+	for i, dkg := range dkgs {
+		if i == idx {
+			continue
+		}
+		ds, err := dkg.Deals()
+		require.NoError(t, err)
+		deals = append(deals, ds[idx])
+	}
+	return deals
+}
+
+func TestDKGProcessDeals(t *testing.T) {
+	partPubs, partSec, dkgs := generate(defaultN, defaultT)
+	// two generators for the first participant, one processes deals serially
+	serial, err := NewDistKeyGenerator(suite, partSec[0], partPubs, defaultT)
+	require.NoError(t, err)
+	parallel, err := NewDistKeyGenerator(suite, partSec[0], partPubs, defaultT)
+	require.NoError(t, err)
+
+	deals := dealsFor(t, dkgs, 0)
+	// a replayed deal and a deal with an invalid signature
+	bad := *deals[1]
+	bad.Signature = randomBytes(len(bad.Signature))
+	deals = append(deals, deals[0], &bad, nil)
+
+	resps, errs := parallel.ProcessDeals(deals)
+	require.Len(t, resps, len(deals))
+	require.Len(t, errs, len(deals))
+	for i, dd := range deals {
+		if dd == nil {
+			require.Error(t, errs[i])
+			continue
+		}
+		expected, expErr := serial.ProcessDeal(dd)
+		if expErr != nil {
+			require.Error(t, errs[i])
+			require.Nil(t, resps[i])
+			continue
+		}
+		require.NoError(t, errs[i])
+		// signatures are randomized, everything else must be identical
+		require.Equal(t, expected.Index, resps[i].Index)
+		require.Equal(t, expected.Response.SessionID, resps[i].Response.SessionID)
+		require.Equal(t, expected.Response.Index, resps[i].Response.Index)
+		require.Equal(t, expected.Response.Status, resps[i].Response.Status)
+		require.NoError(t, schnorr.Verify(suite, partPubs[0], resps[i].Response.Hash(suite), resps[i].Response.Signature))
+	}
+	require.Error(t, errs[len(deals)-3])
+	require.Error(t, errs[len(deals)-2])
+}
+
+// A suite created with a stream shares it between all its callers, which
+// ProcessDeals must not read concurrently. Run with -race.
+func TestDKGProcessDealsSharedStream(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	suite := edwards25519.NewBlakeSHA256Ed25519WithRand(blake2xb.New(nil))
+	partPubs, partSec, dkgs := generate(defaultN, defaultT)
+	dkg, err := NewDistKeyGenerator(suite, partSec[0], partPubs, defaultT)
+	require.NoError(t, err)
+	resps, errs := dkg.ProcessDeals(dealsFor(t, dkgs, 0))
+	for i := range resps {
+		require.NoError(t, errs[i])
+		require.Equal(t, vss.StatusApproval, resps[i].Response.Status)
+	}
+}
+
+func benchmarkProcessDeals(b *testing.B, process func(*DistKeyGenerator, []*Deal)) {
+	n := 128
+	th := vss.MinimumT(n)
+	partPubs, partSec, dkgs := generate(n, th)
+	deals := dealsFor(b, dkgs, 0)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		dkg, err := NewDistKeyGenerator(suite, partSec[0], partPubs, th)
+		require.NoError(b, err)
+		b.StartTimer()
+		process(dkg, deals)
+	}
+}
+
+func BenchmarkDKGProcessDealSerial(b *testing.B) {
+	benchmarkProcessDeals(b, func(dkg *DistKeyGenerator, deals []*Deal) {
+		for _, dd := range deals {
+			if _, err := dkg.ProcessDeal(dd); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkDKGProcessDeals(b *testing.B) {
+	benchmarkProcessDeals(b, func(dkg *DistKeyGenerator, deals []*Deal) {
+		_, errs := dkg.ProcessDeals(deals)
+		for _, err := range errs {
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
 func TestDKGProcessResponse(t *testing.T) {
 	// first peer generates wrong deal
 	// second peer processes it and returns a complaint
@@ -245,6 +355,360 @@ func TestDKGProcessResponse(t *testing.T) {
 
 }
 
+func TestDKGVerifyDealShare(t *testing.T) {
+	partPubs, partSec, dkgs := generate(defaultN, defaultT)
+	dealer := dkgs[0]
+	deals, err := dealer.Deals()
+	require.NoError(t, err)
+
+	for i, dd := range deals {
+		require.NoError(t, VerifyDealShare(suite, partPubs, partPubs, dealer.sid, dd, partSec[i]))
+		// only the recipient can decrypt its deal
+		require.Error(t, VerifyDealShare(suite, partPubs, partPubs, dealer.sid, dd, partSec[(i+1)%defaultN]))
+	}
+
+	// tampered share
+	deal, err := dealer.dealer.PlaintextDeal(1)
+	require.NoError(t, err)
+	goodSecret := deal.SecShare.V
+	deal.SecShare.V = suite.Scalar().Zero()
+	deals, err = dealer.Deals()
+	require.NoError(t, err)
+	require.Error(t, VerifyDealShare(suite, partPubs, partPubs, dealer.sid, deals[1], partSec[1]))
+	deal.SecShare.V = goodSecret
+
+	// invalid signature
+	deals, err = dealer.Deals()
+	require.NoError(t, err)
+	deals[1].Signature = randomBytes(len(deals[1].Signature))
+	require.Error(t, VerifyDealShare(suite, partPubs, partPubs, dealer.sid, deals[1], partSec[1]))
+
+	// deal of another session
+	deals, err = dealer.Deals()
+	require.NoError(t, err)
+	require.EqualError(t, VerifyDealShare(suite, partPubs, partPubs, randomBytes(len(dealer.sid)), deals[1], partSec[1]),
+		"dkg: deal from another session")
+}
+
+func TestDKGBroadcastDeals(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	deals, err := dkgs[0].Deals()
+	require.NoError(t, err)
+
+	// every deal is encrypted to its recipient's longterm key, so a node
+	// reading all of them from a public board can only process its own
+	for i, deal := range deals {
+		for j := 1; j < defaultN; j++ {
+			if j == i {
+				continue
+			}
+			resp, err := dkgs[j].ProcessDeal(deal)
+			require.Nil(t, resp)
+			require.Error(t, err)
+		}
+		resp, err := dkgs[i].ProcessDeal(deal)
+		require.NoError(t, err)
+		require.Equal(t, vss.StatusApproval, resp.Response.Status)
+	}
+}
+
+func TestDKGMinResponses(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	quorum := defaultN * defaultN
+	rec := dkgs[0]
+	rec.c.MinResponses = quorum
+
+	var resps []*Response
+	for _, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		for i, d := range deals {
+			resp, err := dkgs[i].ProcessDeal(d)
+			require.NoError(t, err)
+			if i != 0 {
+				resps = append(resps, resp)
+			}
+		}
+	}
+	// rec processed its own deal and holds its n responses
+	_, err := rec.DistKeyShare()
+	require.Equal(t, ErrNotEnoughResponses, err)
+
+	// below the quorum, even after the timeout
+	for _, resp := range resps[:len(resps)-1] {
+		_, err := rec.ProcessResponse(resp)
+		require.NoError(t, err)
+	}
+	require.Equal(t, quorum-1, rec.receivedResponses())
+	rec.SetTimeout()
+	_, err = rec.DistKeyShare()
+	require.Equal(t, ErrNotEnoughResponses, err)
+
+	// at the quorum
+	_, err = rec.ProcessResponse(resps[len(resps)-1])
+	require.NoError(t, err)
+	dks, err := rec.DistKeyShare()
+	require.NoError(t, err)
+	require.NotNil(t, dks)
+}
+
+func TestDKGDealOtherThreshold(t *testing.T) {
+	partPubs, partSec, dkgs := generate(defaultN, defaultT)
+	// dealer 0 shares its secret with a polynomial of a higher degree
+	dealer, err := NewDistKeyGenerator(suite, partSec[0], partPubs, defaultT+1)
+	require.NoError(t, err)
+	deals, err := dealer.Deals()
+	require.NoError(t, err)
+	for i, deal := range deals {
+		// its config has another session ID
+		_, err := dkgs[i].ProcessDeal(deal)
+		require.EqualError(t, err, "dkg: deal from another session")
+
+		// and the deal is caught even if it claims the session of the group
+		deal.SessionID = dkgs[i].sid
+		buff, err := deal.MarshalBinary()
+		require.NoError(t, err)
+		deal.Signature, err = schnorr.Sign(suite, partSec[0], buff)
+		require.NoError(t, err)
+		resp, err := dkgs[i].ProcessDeal(deal)
+		require.NoError(t, err)
+		require.Equal(t, vss.StatusComplaint, resp.Response.Status)
+	}
+}
+
+func TestConfigSessionID(t *testing.T) {
+	pubs, privs, _ := generate(defaultN, defaultT)
+	c := &Config{
+		Suite:     suite,
+		Longterm:  privs[0],
+		NewNodes:  pubs,
+		Threshold: defaultT,
+		Nonce:     []byte("nonce"),
+	}
+	sessionID := func(c *Config) []byte {
+		sid, err := c.SessionID()
+		require.NoError(t, err)
+		return sid
+	}
+	sid := sessionID(c)
+	require.Len(t, sid, suite.Hash().Size())
+
+	// stable under the order of the nodes and the node using the config
+	reversed := make([]kyber.Point, len(pubs))
+	for i, p := range pubs {
+		reversed[len(pubs)-1-i] = p
+	}
+	other := *c
+	other.NewNodes, other.Longterm = reversed, privs[1]
+	require.Equal(t, sid, sessionID(&other))
+
+	// and across the construction of a DistKeyGenerator
+	dkg, err := NewDistKeyHandler(c)
+	require.NoError(t, err)
+	require.Equal(t, sid, dkg.sid)
+	require.Equal(t, sid, sessionID(c))
+
+	// but bound to the nodes, the threshold and the nonce
+	other = *c
+	other.OldNodes = nil
+	other.NewNodes = pubs[1:]
+	require.NotEqual(t, sid, sessionID(&other))
+	other = *c
+	other.Threshold = defaultT + 1
+	require.NotEqual(t, sid, sessionID(&other))
+	other = *c
+	other.Nonce = []byte("other nonce")
+	require.NotEqual(t, sid, sessionID(&other))
+
+	// but not to OldThreshold, which a fresh DKG ignores
+	other = *c
+	other.OldThreshold = defaultT
+	require.Equal(t, sid, sessionID(&other))
+	// unlike a resharing
+	other.PublicCoeffs = []kyber.Point{pubs[0]}
+	resharing := sessionID(&other)
+	other.OldThreshold = defaultT + 1
+	require.NotEqual(t, resharing, sessionID(&other))
+
+	// a key that cannot be marshalled is an error
+	other = *c
+	other.NewNodes = append([]kyber.Point{badPoint{pubs[0]}}, pubs[1:]...)
+	_, err = other.SessionID()
+	require.Error(t, err)
+
+	// deals of a session are rejected in another one
+	dkg2, err := NewDistKeyHandler(&Config{
+		Suite:     suite,
+		Longterm:  privs[1],
+		NewNodes:  pubs,
+		Threshold: defaultT,
+	})
+	require.NoError(t, err)
+	deals, err := dkg.Deals()
+	require.NoError(t, err)
+	_, err = dkg2.ProcessDeal(deals[1])
+	require.EqualError(t, err, "dkg: deal from another session")
+}
+
+// badPoint is a point that cannot be marshalled.
+type badPoint struct {
+	kyber.Point
+}
+
+func (badPoint) MarshalBinary() ([]byte, error) {
+	return nil, errors.New("bad point")
+}
+
+func TestDKGRotatedKeys(t *testing.T) {
+	pubs, privs, dkgs := generate(defaultN, defaultT)
+	deals, err := dkgs[0].Deals()
+	require.NoError(t, err)
+
+	// dealer 0 signs with its new key while the old one is still listed
+	newPriv, newPub := genPair()
+	dd := deals[1]
+	buff, err := dd.MarshalBinary()
+	require.NoError(t, err)
+	dd.Signature, err = schnorr.Sign(suite, newPriv, buff)
+	require.NoError(t, err)
+
+	_, err = dkgs[1].ProcessDeal(dd)
+	require.Error(t, err)
+
+	c := &Config{
+		Suite:       suite,
+		Longterm:    privs[1],
+		NewNodes:    pubs,
+		Threshold:   defaultT,
+		RotatedKeys: map[uint32][]kyber.Point{0: {newPub}},
+	}
+	dkg, err := NewDistKeyHandler(c)
+	require.NoError(t, err)
+	resp, err := dkg.ProcessDeal(dd)
+	require.NoError(t, err)
+	require.Equal(t, vss.StatusApproval, resp.Response.Status)
+
+	// the new key of dealer 0 is not accepted for another dealer
+	deals, err = dkgs[2].Deals()
+	require.NoError(t, err)
+	dd = deals[1]
+	buff, err = dd.MarshalBinary()
+	require.NoError(t, err)
+	dd.Signature, err = schnorr.Sign(suite, newPriv, buff)
+	require.NoError(t, err)
+	_, err = dkg.ProcessDeal(dd)
+	require.Error(t, err)
+}
+
+func TestDKGMaxNodes(t *testing.T) {
+	require.NoError(t, checkMaxNodes(make([]kyber.Point, MaxNodes), "NewNodes"))
+	require.Error(t, checkMaxNodes(make([]kyber.Point, MaxNodes+1), "NewNodes"))
+
+	partPubs, partSec, _ := generate(defaultN, defaultT)
+	tooMany := make([]kyber.Point, MaxNodes+1)
+	copy(tooMany, partPubs)
+	for i := len(partPubs); i < len(tooMany); i++ {
+		tooMany[i] = suite.Point().Null()
+	}
+	_, err := NewDistKeyHandler(&Config{
+		Suite:    suite,
+		Longterm: partSec[0],
+		NewNodes: tooMany,
+	})
+	require.EqualError(t, err, fmt.Sprintf("dkg: %d nodes in NewNodes, more than the maximum of %d", MaxNodes+1, MaxNodes))
+	_, err = NewDistKeyHandler(&Config{
+		Suite:     suite,
+		Longterm:  partSec[0],
+		OldNodes:  tooMany,
+		NewNodes:  partPubs,
+		Threshold: defaultT,
+	})
+	require.Error(t, err)
+}
+
+func TestDKGBatchVerifyDeals(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	idx := 2
+	deals := make([]*vss.Deal, 0, defaultN)
+	for _, dkg := range dkgs {
+		deal, err := dkg.dealer.PlaintextDeal(idx)
+		require.NoError(t, err)
+		deals = append(deals, deal)
+	}
+	require.NoError(t, BatchVerifyDeals(suite, deals, idx))
+	require.NoError(t, BatchVerifyDeals(suite, nil, idx))
+
+	// wrong index
+	require.Error(t, BatchVerifyDeals(suite, deals, idx+1))
+
+	// one bad dealer
+	goodSecret := deals[3].SecShare.V
+	deals[3].SecShare.V = suite.Scalar().Add(goodSecret, suite.Scalar().One())
+	require.Error(t, BatchVerifyDeals(suite, deals, idx))
+	deals[3].SecShare.V = goodSecret
+
+	goodCommit := deals[1].Commitments[1]
+	deals[1].Commitments[1] = suite.Point().Base()
+	require.Error(t, BatchVerifyDeals(suite, deals, idx))
+	deals[1].Commitments[1] = goodCommit
+	require.NoError(t, BatchVerifyDeals(suite, deals, idx))
+}
+
+// Test that all nodes agree on the same QUAL set when deals are borderline
+// certified and messages are received in different orders.
+func TestDKGQUALDeterministic(t *testing.T) {
+	n := 5
+	thr := 3
+	_, _, dkgs := generate(n, thr)
+
+	// dealer 1 loses exactly n-t responses, which is still acceptable after the
+	// timeout, whereas dealer 3 loses more and can not be certified.
+	dropped := map[uint32]map[uint32]bool{
+		1: {2: true, 3: true},
+		3: {0: true, 1: true, 2: true, 4: true},
+	}
+
+	var resps []*Response
+	for _, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		for i, d := range deals {
+			resp, err := dkgs[i].ProcessDeal(d)
+			require.NoError(t, err)
+			if dropped[resp.Index][resp.Response.Index] {
+				continue
+			}
+			resps = append(resps, resp)
+		}
+	}
+
+	for _, dkg := range dkgs {
+		order := mathRand.Perm(len(resps))
+		for _, k := range order {
+			resp := resps[k]
+			if resp.Response.Index == uint32(dkg.nidx) {
+				continue
+			}
+			j, err := dkg.ProcessResponse(resp)
+			require.NoError(t, err)
+			require.Nil(t, j)
+		}
+		dkg.SetTimeout()
+	}
+
+	expected := []int{0, 1, 2, 4}
+	for _, dkg := range dkgs {
+		require.Equal(t, expected, dkg.QUAL())
+	}
+	dks0, err := dkgs[0].DistKeyShare()
+	require.NoError(t, err)
+	for _, dkg := range dkgs[1:] {
+		dks, err := dkg.DistKeyShare()
+		require.NoError(t, err)
+		require.True(t, dks.Public().Equal(dks0.Public()))
+	}
+}
+
 // Test Resharing to a group with one mode node BUT only a threshold of dealers
 // are present during the resharing.
 func TestDKGResharingThreshold(t *testing.T) {
@@ -670,6 +1134,51 @@ func TestDKGResharing(t *testing.T) {
 	require.Equal(t, oldSecret.String(), newSecret.String())
 }
 
+func TestDKGVerifyReshareConsistency(t *testing.T) {
+	oldT := vss.MinimumT(defaultN)
+	publics, secrets, dkgs := generate(defaultN, oldT)
+	fullExchange(t, dkgs, true)
+
+	shares := make([]*DistKeyShare, len(dkgs))
+	newDkgs := make([]*DistKeyGenerator, len(dkgs))
+	for i, dkg := range dkgs {
+		dks, err := dkg.DistKeyShare()
+		require.NoError(t, err)
+		shares[i] = dks
+		c := &Config{
+			Suite:        suite,
+			Longterm:     secrets[i],
+			OldNodes:     publics,
+			NewNodes:     publics,
+			Share:        shares[i],
+			OldThreshold: oldT,
+		}
+		newDkgs[i], err = NewDistKeyHandler(c)
+		require.NoError(t, err)
+	}
+	fullExchange(t, newDkgs, true)
+
+	oldPub := share.NewPubPoly(suite, nil, shares[0].Commits)
+	for _, dkg := range newDkgs {
+		dks, err := dkg.DistKeyShare()
+		require.NoError(t, err)
+		newPub := share.NewPubPoly(suite, nil, dks.Commits)
+		require.NoError(t, VerifyReshareConsistency(oldPub, newPub, dks.Share))
+
+		// a tampered share is detected
+		tampered := &share.PriShare{I: dks.Share.I, V: suite.Scalar().Add(dks.Share.V, suite.Scalar().One())}
+		require.Error(t, VerifyReshareConsistency(oldPub, newPub, tampered))
+	}
+
+	// so is a new polynomial sharing another key, even with a valid share
+	_, _, others := generate(defaultN, oldT)
+	fullExchange(t, others, true)
+	other, err := others[0].DistKeyShare()
+	require.NoError(t, err)
+	otherPub := share.NewPubPoly(suite, nil, other.Commits)
+	require.Error(t, VerifyReshareConsistency(oldPub, otherPub, other.Share))
+}
+
 // Test resharing functionality with one node less
 func TestDKGResharingRemoveNode(t *testing.T) {
 	oldT := vss.MinimumT(defaultN)
@@ -731,6 +1240,84 @@ func TestDKGResharingRemoveNode(t *testing.T) {
 	require.Equal(t, oldSecret.String(), newSecret.String())
 }
 
+func TestDKGSortNodes(t *testing.T) {
+	pubs := make([]kyber.Point, defaultN)
+	for i := range pubs {
+		_, pubs[i] = genPair()
+	}
+	sorted, err := SortNodes(pubs)
+	require.NoError(t, err)
+	require.Len(t, sorted, defaultN)
+
+	for i := 0; i < 10; i++ {
+		shuffled := make([]kyber.Point, len(pubs))
+		for j, k := range mathRand.Perm(len(pubs)) {
+			shuffled[j] = pubs[k]
+		}
+		other, err := SortNodes(shuffled)
+		require.NoError(t, err)
+		for j := range sorted {
+			require.True(t, sorted[j].Equal(other[j]))
+		}
+	}
+
+	_, err = SortNodes(append(pubs, pubs[2].Clone()))
+	require.True(t, errors.Is(err, ErrDuplicateNode))
+}
+
+func TestDKGDuplicateNode(t *testing.T) {
+	pubs, privs, _ := generate(defaultN, defaultT)
+	nodes := append(append([]kyber.Point{}, pubs...), pubs[1].Clone())
+	_, err := NewDistKeyHandler(&Config{
+		Suite:     suite,
+		Longterm:  privs[0],
+		NewNodes:  nodes,
+		Threshold: defaultT,
+	})
+	require.True(t, errors.Is(err, ErrDuplicateNode))
+	require.Contains(t, err.Error(), fmt.Sprintf("indices 1 and %d of NewNodes", defaultN))
+}
+
+func TestDKGCanIssue(t *testing.T) {
+	oldPubs, oldPrivs, dkgs := generate(defaultN, defaultT)
+	for _, dkg := range dkgs {
+		require.True(t, dkg.CanIssue())
+		require.Equal(t, defaultN-1, dkg.ExpectedDeals())
+	}
+	fullExchange(t, dkgs, true)
+	dks, err := dkgs[0].DistKeyShare()
+	require.NoError(t, err)
+
+	// a node that only joins the new group receives a deal from every old
+	// node but can not issue any
+	newPriv, newPub := genPair()
+	c := &Config{
+		Suite:        suite,
+		Longterm:     newPriv,
+		OldNodes:     oldPubs,
+		NewNodes:     append([]kyber.Point{newPub}, oldPubs...),
+		PublicCoeffs: dks.Commits,
+		Threshold:    defaultT,
+		OldThreshold: defaultT,
+	}
+	newDkg, err := NewDistKeyHandler(c)
+	require.NoError(t, err)
+	require.False(t, newDkg.CanIssue())
+	require.Equal(t, defaultN, newDkg.ExpectedDeals())
+	deals, err := newDkg.Deals()
+	require.NoError(t, err)
+	require.Empty(t, deals)
+
+	// an old node staying in the new group is a dealer too
+	c.Longterm = oldPrivs[0]
+	c.Share = dks
+	c.PublicCoeffs = nil
+	oldDkg, err := NewDistKeyHandler(c)
+	require.NoError(t, err)
+	require.True(t, oldDkg.CanIssue())
+	require.Equal(t, defaultN-1, oldDkg.ExpectedDeals())
+}
+
 // Test to reshare to a different set of nodes with only a threshold of the old
 // nodes present
 func TestDKGResharingNewNodesThreshold(t *testing.T) {
@@ -1366,3 +1953,67 @@ func TestUserOnlyFlagFalseBehavior(t *testing.T) {
 
 	require.False(t, dkg1.dealer.PrivatePoly().Secret().Equal(dkg2.dealer.PrivatePoly().Secret()))
 }
+
+// TestDKGUserReaderOnlyReproducible runs the same DKG twice, each node drawing all its randomness
+// from a seeded reader, and checks that all messages and shares are equal.
+func TestDKGUserReaderOnlyReproducible(t *testing.T) {
+	partPubs, partSec, _ := generate(defaultN, defaultT)
+	run := func() ([][]byte, []*DistKeyShare) {
+		dkgs := make([]*DistKeyGenerator, defaultN)
+		for i := range dkgs {
+			dkg, err := NewDistKeyHandler(&Config{
+				Suite:          suite,
+				Longterm:       partSec[i],
+				NewNodes:       partPubs,
+				Threshold:      defaultT,
+				Reader:         blake2xb.New([]byte{byte(i)}),
+				UserReaderOnly: true,
+			})
+			require.NoError(t, err)
+			dkgs[i] = dkg
+		}
+		var msgs [][]byte
+		var resps []*Response
+		for _, dkg := range dkgs {
+			deals, err := dkg.Deals()
+			require.NoError(t, err)
+			for i := 0; i < defaultN; i++ {
+				d, ok := deals[i]
+				if !ok {
+					continue
+				}
+				buff, err := d.MarshalBinary()
+				require.NoError(t, err)
+				msgs = append(msgs, buff, d.Signature)
+				resp, err := dkgs[i].ProcessDeal(d)
+				require.NoError(t, err)
+				msgs = append(msgs, resp.Response.Signature)
+				resps = append(resps, resp)
+			}
+		}
+		for _, resp := range resps {
+			for _, dkg := range dkgs {
+				if resp.Response.Index == uint32(dkg.nidx) {
+					continue
+				}
+				_, err := dkg.ProcessResponse(resp)
+				require.NoError(t, err)
+			}
+		}
+		dkss := make([]*DistKeyShare, defaultN)
+		for i, dkg := range dkgs {
+			dks, err := dkg.DistKeyShare()
+			require.NoError(t, err)
+			dkss[i] = dks
+		}
+		return msgs, dkss
+	}
+
+	msgs1, dkss1 := run()
+	msgs2, dkss2 := run()
+	require.Equal(t, msgs1, msgs2)
+	for i := range dkss1 {
+		require.True(t, checkDks(dkss1[i], dkss2[i]))
+		require.True(t, dkss1[i].Share.V.Equal(dkss2[i].Share.V))
+	}
+}
@@ -0,0 +1,102 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+// PackDeals encodes several deals, usually from different dealers to the
+// same recipient, into a single message that a relay can forward in one
+// round trip. The encoding is the number of deals followed by each deal:
+// the index of its dealer, its length-prefixed session ID, the
+// length-prefixed DH key, signature, nonce and cipher of the encrypted deal,
+// then the signature of the deal, all integers being uint32 in little
+// endian. The deals are kept as is, so that the recipient verifies the
+// signature of each of them in ProcessDeal.
+func PackDeals(deals []*Deal) ([]byte, error) {
+	var b bytes.Buffer
+	_ = binary.Write(&b, binary.LittleEndian, uint32(len(deals)))
+	for i, d := range deals {
+		if d == nil || d.Deal == nil {
+			return nil, fmt.Errorf("dkg: cannot pack nil deal %d", i)
+		}
+		_ = binary.Write(&b, binary.LittleEndian, d.Index)
+		for _, f := range [][]byte{d.SessionID, d.Deal.DHKey, d.Deal.Signature, d.Deal.Nonce, d.Deal.Cipher, d.Signature} {
+			_ = binary.Write(&b, binary.LittleEndian, uint32(len(f)))
+			b.Write(f)
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// errPacked is returned when a packed message is malformed.
+var errPacked = errors.New("dkg: malformed packed deals")
+
+// UnpackDeals decodes a message created by PackDeals.
+func UnpackDeals(buf []byte) ([]*Deal, error) {
+	r := bytes.NewReader(buf)
+	readUint32 := func() (uint32, error) {
+		var v uint32
+		if err := binary.Read(r, binary.LittleEndian, &v); err != nil {
+			return 0, errPacked
+		}
+		return v, nil
+	}
+	readBytes := func() ([]byte, error) {
+		l, err := readUint32()
+		if err != nil {
+			return nil, err
+		}
+		if int64(l) > int64(r.Len()) {
+			return nil, errPacked
+		}
+		f := make([]byte, l)
+		_, _ = r.Read(f)
+		return f, nil
+	}
+
+	n, err := readUint32()
+	if err != nil {
+		return nil, err
+	}
+	// every deal takes at least 28 bytes, which bounds the allocation
+	if int64(n)*28 > int64(r.Len()) {
+		return nil, errPacked
+	}
+	deals := make([]*Deal, n)
+	for i := range deals {
+		d := &Deal{Deal: new(vss.EncryptedDeal)}
+		if d.Index, err = readUint32(); err != nil {
+			return nil, err
+		}
+		for _, f := range []*[]byte{&d.SessionID, &d.Deal.DHKey, &d.Deal.Signature, &d.Deal.Nonce, &d.Deal.Cipher, &d.Signature} {
+			if *f, err = readBytes(); err != nil {
+				return nil, err
+			}
+		}
+		deals[i] = d
+	}
+	if r.Len() != 0 {
+		return nil, errPacked
+	}
+	return deals, nil
+}
+
+// PushPackedDeals unpacks a message created by PackDeals and pushes each of
+// its deals to the board. Nothing is pushed if the message is malformed.
+func PushPackedDeals(b Board, buf []byte) error {
+	deals, err := UnpackDeals(buf)
+	if err != nil {
+		return err
+	}
+	for _, d := range deals {
+		if err := b.PushDeal(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+func TestPackDeals(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+
+	// a relay bundles the deals of three dealers for node 3
+	var deals []*Deal
+	for _, dkg := range dkgs[:3] {
+		dd, err := dkg.Deals()
+		require.NoError(t, err)
+		deals = append(deals, dd[3])
+	}
+	buf, err := PackDeals(deals)
+	require.NoError(t, err)
+
+	unpacked, err := UnpackDeals(buf)
+	require.NoError(t, err)
+	require.Equal(t, deals, unpacked)
+
+	board := NewSafeBoard(len(deals))
+	require.NoError(t, PushPackedDeals(board, buf))
+	board.Close()
+	var n int
+	for d := range board.IncomingDeal() {
+		resp, err := dkgs[3].ProcessDeal(d)
+		require.NoError(t, err)
+		require.Equal(t, vss.StatusApproval, resp.Response.Status)
+		n++
+	}
+	require.Equal(t, len(deals), n)
+
+	// truncated or extended messages are rejected
+	for _, b := range [][]byte{nil, buf[:len(buf)-1], append(buf, 0)} {
+		_, err := UnpackDeals(b)
+		require.Error(t, err)
+	}
+	empty, err := PackDeals(nil)
+	require.NoError(t, err)
+	unpacked, err = UnpackDeals(empty)
+	require.NoError(t, err)
+	require.Len(t, unpacked, 0)
+}
@@ -0,0 +1,63 @@
+package dkg
+
+import "fmt"
+
+// Replay runs a fresh DistKeyGenerator created from c over the messages
+// captured during a DKG, for example from a public board, and returns the
+// Result the node obtained. deals are the deals received by the node, resps
+// and justs all the responses and justifications that were broadcast. The
+// node's own deal and its own responses, which the generator creates
+// itself, are skipped if they are present.
+//
+// The messages are processed in the order deals, responses then
+// justifications, the latter in the canonical order of
+// ProcessJustifications, and the timeout is set at the end, since no other
+// message can arrive. The returned error tells which message could not be
+// processed, or why no distributed key can be computed from the messages.
+// A nil deal or response is reported as such with its index.
+//
+// The responses on the node's own deal can only be processed if the
+// generator recreates the same deal as in the captured run, which requires
+// c to hold a Reader giving the same bytes with UserReaderOnly set.
+func Replay(c *Config, deals []*Deal, resps []*Response, justs []*Justification) (*Result, error) {
+	d, err := NewDistKeyHandler(c)
+	if err != nil {
+		return nil, err
+	}
+	// process the own deal of the node
+	if _, err := d.Deals(); err != nil {
+		return nil, err
+	}
+
+	for i, dd := range deals {
+		if dd == nil {
+			return nil, fmt.Errorf("dkg: replay of deal %d: nil deal", i)
+		}
+		if d.canIssue && dd.Index == uint32(d.oidx) {
+			continue
+		}
+		if _, err := d.ProcessDeal(dd); err != nil {
+			return nil, fmt.Errorf("dkg: replay of deal %d from dealer %d: %v", i, dd.Index, err)
+		}
+	}
+	for i, resp := range resps {
+		if resp == nil || resp.Response == nil {
+			return nil, fmt.Errorf("dkg: replay of response %d: nil response", i)
+		}
+		if d.canReceive && resp.Response.Index == uint32(d.nidx) {
+			continue
+		}
+		if _, err := d.ProcessResponse(resp); err != nil {
+			return nil, fmt.Errorf("dkg: replay of response %d from verifier %d on dealer %d: %v",
+				i, resp.Response.Index, resp.Index, err)
+		}
+	}
+	for i, err := range d.ProcessJustifications(justs) {
+		if err != nil {
+			return nil, fmt.Errorf("dkg: replay of justification %d: %v", i, err)
+		}
+	}
+
+	d.SetTimeout()
+	return d.Result()
+}
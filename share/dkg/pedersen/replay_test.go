@@ -0,0 +1,179 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
+)
+
+func TestDKGReplayBoard(t *testing.T) {
+	partPubs, partSec, _ := generate(defaultN, defaultT)
+	config := func(i int) *Config {
+		return &Config{
+			Suite:          suite,
+			Longterm:       partSec[i],
+			NewNodes:       partPubs,
+			Threshold:      defaultT,
+			Reader:         blake2xb.New([]byte{byte(i)}),
+			UserReaderOnly: true,
+		}
+	}
+
+	// a live run, capturing the deals received by node 0 and all the
+	// responses broadcast
+	dkgs := make([]*DistKeyGenerator, defaultN)
+	for i := range dkgs {
+		dkg, err := NewDistKeyHandler(config(i))
+		require.NoError(t, err)
+		dkgs[i] = dkg
+	}
+	var deals []*Deal
+	var resps []*Response
+	for _, dkg := range dkgs {
+		dd, err := dkg.Deals()
+		require.NoError(t, err)
+		for i, d := range dd {
+			if i == 0 {
+				deals = append(deals, d)
+			}
+			resp, err := dkgs[i].ProcessDeal(d)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+	}
+	for _, resp := range resps {
+		for _, dkg := range dkgs {
+			if resp.Response.Index == uint32(dkg.nidx) {
+				continue
+			}
+			_, err := dkg.ProcessResponse(resp)
+			require.NoError(t, err)
+		}
+	}
+	live, err := dkgs[0].Result()
+	require.NoError(t, err)
+
+	// the replay gives the same result
+	res, err := Replay(config(0), deals, resps, nil)
+	require.NoError(t, err)
+	require.Equal(t, live.QUAL, res.QUAL)
+	require.True(t, checkDks(live.Key, res.Key))
+	require.True(t, live.Key.Share.V.Equal(res.Key.Share.V))
+
+	// a failed run, where the other nodes stopped after sending their deals
+	_, err = Replay(config(0), deals, nil, nil)
+	require.EqualError(t, err, "dkg: distributed key not certified")
+
+	// a corrupted message is reported
+	bad := *deals[1]
+	bad.Signature = randomBytes(len(bad.Signature))
+	_, err = Replay(config(0), []*Deal{deals[0], &bad}, resps, nil)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "dkg: replay of deal 1 from dealer")
+
+	// nil messages are reported with their index
+	_, err = Replay(config(0), []*Deal{deals[0], nil}, resps, nil)
+	require.EqualError(t, err, "dkg: replay of deal 1: nil deal")
+	_, err = Replay(config(0), deals, append(resps[:2:2], nil), nil)
+	require.EqualError(t, err, "dkg: replay of response 2: nil response")
+	_, err = Replay(config(0), deals, []*Response{{Index: 1}}, nil)
+	require.EqualError(t, err, "dkg: replay of response 0: nil response")
+
+	// without the same reader, the own deal of the node differs from the
+	// captured one
+	c := config(0)
+	c.Reader, c.UserReaderOnly = nil, false
+	_, err = Replay(c, deals, resps, nil)
+	require.Error(t, err)
+}
+
+func TestDKGReplayJustificationOrder(t *testing.T) {
+	partPubs, partSec, _ := generate(defaultN, defaultT)
+	config := func(i int) *Config {
+		return &Config{
+			Suite:          suite,
+			Longterm:       partSec[i],
+			NewNodes:       partPubs,
+			Threshold:      defaultT,
+			Reader:         blake2xb.New([]byte{byte(i)}),
+			UserReaderOnly: true,
+		}
+	}
+	dkgs := make([]*DistKeyGenerator, defaultN)
+	for i := range dkgs {
+		dkg, err := NewDistKeyHandler(config(i))
+		require.NoError(t, err)
+		dkgs[i] = dkg
+	}
+
+	// dealers 0 and 2 send a bad share to nodes 1 and 3
+	bad := map[int]int{0: 1, 2: 3}
+	var deals []*Deal
+	var resps []*Response
+	for i, dkg := range dkgs {
+		var deal *vss.Deal
+		var good kyber.Scalar
+		if r, ok := bad[i]; ok {
+			var err error
+			deal, err = dkg.dealer.PlaintextDeal(r)
+			require.NoError(t, err)
+			good = deal.SecShare.V
+			deal.SecShare.V = suite.Scalar().Zero()
+		}
+		dd, err := dkg.Deals()
+		require.NoError(t, err)
+		if deal != nil {
+			deal.SecShare.V = good
+		}
+		for j, d := range dd {
+			if j == 4 {
+				deals = append(deals, d)
+			}
+			resp, err := dkgs[j].ProcessDeal(d)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+	}
+	var justs []*Justification
+	for _, resp := range resps {
+		r := *resp.Response
+		if j, err := dkgs[resp.Index].ProcessResponse(&Response{Index: resp.Index, Response: &r}); err == nil && j != nil {
+			justs = append(justs, j)
+		}
+	}
+	require.Len(t, justs, 2)
+
+	// every replay gets its own copy of the responses, since processing a
+	// justification modifies the complaint it answers
+	replay := func(justs []*Justification) *Result {
+		rs := make([]*Response, len(resps))
+		for i, resp := range resps {
+			r := *resp.Response
+			rs[i] = &Response{Index: resp.Index, Response: &r}
+		}
+		res, err := Replay(config(4), deals, rs, justs)
+		require.NoError(t, err)
+		return res
+	}
+	forward := replay(justs)
+	reversed := replay([]*Justification{justs[1], justs[0]})
+	require.Equal(t, forward.QUAL, reversed.QUAL)
+	require.Len(t, forward.QUAL, defaultN)
+	require.True(t, checkDks(forward.Key, reversed.Key))
+	require.True(t, forward.Key.Share.V.Equal(reversed.Key.Share.V))
+
+	for _, resp := range resps {
+		// node 4 already processed the responses to its own deal
+		if resp.Response.Index != 4 && resp.Index != 4 {
+			_, err := dkgs[4].ProcessResponse(resp)
+			require.NoError(t, err)
+		}
+	}
+	errs := dkgs[4].ProcessJustifications([]*Justification{nil, justs[1], justs[0]})
+	require.Error(t, errs[0])
+	require.NoError(t, errs[1])
+	require.NoError(t, errs[2])
+}
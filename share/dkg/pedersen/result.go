@@ -0,0 +1,289 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/util/encoding"
+)
+
+// Result holds what a node needs to persist at the end of a DKG: its
+// distributed key share and the QUAL set of dealers it was built from.
+// A Result can be stored and loaded back with encoding/json. The JSON form
+// contains the private share of the node and must be kept secret.
+type Result struct {
+	// QUAL is the list of dealers whose deals were aggregated, see
+	// DistKeyGenerator.QUAL.
+	QUAL []int
+	// Key is the distributed key share of the node.
+	Key *DistKeyShare
+
+	suite Suite
+}
+
+// NewResult returns an empty Result for the given suite, to be filled by
+// json.Unmarshal.
+func NewResult(suite Suite) *Result {
+	return &Result{suite: suite}
+}
+
+// Result returns the Result of this DKG. It returns an error under the same
+// conditions as DistKeyShare.
+func (d *DistKeyGenerator) Result() (*Result, error) {
+	dks, err := d.DistKeyShare()
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		QUAL:  d.QUAL(),
+		Key:   dks,
+		suite: d.suite,
+	}, nil
+}
+
+// VerifyPubShares checks that the given public shares of the participants
+// Lagrange-combine, with share.RecoverCommit, to the distributed public key
+// of the result. Any t valid public shares among the n participants are
+// enough.
+func (r *Result) VerifyPubShares(pubShares []*share.PubShare, t, n int) error {
+	if r.suite == nil || r.Key == nil || len(r.Key.Commits) == 0 {
+		return errors.New("dkg: incomplete result")
+	}
+	pub, err := share.RecoverCommit(r.suite, pubShares, t, n)
+	if err != nil {
+		return err
+	}
+	if !pub.Equal(r.Key.Public()) {
+		return errors.New("dkg: public shares do not recover the distributed public key")
+	}
+	return nil
+}
+
+// ProveShare returns a Schnorr proof that the node knows the private share
+// behind its public share, i.e. the evaluation of the distributed public
+// polynomial at its index, without revealing the share. The proof is bound
+// to the index and to context; to show that the node is live, the verifier
+// should pass a fresh challenge as context.
+func (r *Result) ProveShare(context []byte) ([]byte, error) {
+	if r.suite == nil || r.Key == nil || r.Key.Share == nil {
+		return nil, errors.New("dkg: incomplete result")
+	}
+	return schnorr.Sign(r.suite, r.Key.Share.V, shareProofMsg(r.Key.Share.I, context))
+}
+
+// VerifyShareProof checks a proof created with Result.ProveShare by the
+// holder of the public share pubShare, for the same context. The public share
+// of node i can be computed from the public polynomial, e.g. with
+// share.NewPubPoly(suite, nil, commits).Eval(i).
+func VerifyShareProof(suite Suite, pubShare *share.PubShare, context, proof []byte) error {
+	if pubShare == nil || pubShare.V == nil {
+		return errors.New("dkg: missing public share")
+	}
+	return schnorr.Verify(suite, pubShare.V, shareProofMsg(pubShare.I, context), proof)
+}
+
+func shareProofMsg(index int, context []byte) []byte {
+	var b bytes.Buffer
+	b.WriteString("dkg share proof")
+	_ = binary.Write(&b, binary.LittleEndian, uint32(index))
+	b.Write(context)
+	return b.Bytes()
+}
+
+// VerifyResults checks that the results of all the participants of a DKG are
+// consistent with each other: they must share the same QUAL set and the same
+// public polynomial, and the private share of each result must match the
+// public share given by that polynomial at its index. It returns an error
+// naming the first inconsistent result.
+func VerifyResults(results []*Result) error {
+	if len(results) == 0 {
+		return errors.New("dkg: no results to verify")
+	}
+	for i, r := range results {
+		if r == nil || r.suite == nil || r.Key == nil || r.Key.Share == nil {
+			return fmt.Errorf("dkg: result %d is incomplete", i)
+		}
+	}
+	first := results[0]
+	suite := first.suite
+	pubPoly := share.NewPubPoly(suite, nil, first.Key.Commits)
+	seen := make(map[int]bool, len(results))
+	for i, r := range results {
+		if r.suite.String() != suite.String() {
+			return fmt.Errorf("dkg: result %d uses suite %s instead of %s", i, r.suite.String(), suite.String())
+		}
+		if !equalQUAL(first.QUAL, r.QUAL) {
+			return fmt.Errorf("dkg: result %d has a different QUAL set", i)
+		}
+		if !equalCommits(first.Key.Commits, r.Key.Commits) {
+			return fmt.Errorf("dkg: result %d has a different public polynomial", i)
+		}
+		idx := r.Key.Share.I
+		if seen[idx] {
+			return fmt.Errorf("dkg: result %d has duplicate share index %d", i, idx)
+		}
+		seen[idx] = true
+		pub := suite.Point().Mul(r.Key.Share.V, nil)
+		if !pub.Equal(pubPoly.Eval(idx).V) {
+			return fmt.Errorf("dkg: result %d has a share inconsistent with the public polynomial", i)
+		}
+	}
+	return nil
+}
+
+// VerifyPublicResult checks, without any message of the DKG, that the
+// public polynomial pub is a well-formed outcome of a DKG among nodes with
+// threshold t: the node list holds no duplicate, t is between 1 and the
+// number of nodes, pub is committed with respect to the standard base point
+// and has exactly t coefficients, each of them in the prime-order subgroup,
+// and the public key is not the identity.
+func VerifyPublicResult(suite Suite, pub *share.PubPoly, nodes []kyber.Point, t int) error {
+	if len(nodes) == 0 {
+		return errors.New("dkg: no nodes")
+	}
+	if err := checkMaxNodes(nodes, "nodes"); err != nil {
+		return err
+	}
+	if err := checkDuplicates(nodes, "nodes"); err != nil {
+		return err
+	}
+	if t < 1 || t > len(nodes) {
+		return fmt.Errorf("dkg: invalid threshold %d for %d nodes", t, len(nodes))
+	}
+	if pub == nil {
+		return errors.New("dkg: no public polynomial")
+	}
+	base, commits := pub.Info()
+	if base != nil && !base.Equal(suite.Point().Base()) {
+		return errors.New("dkg: public polynomial not committed to the standard base")
+	}
+	if len(commits) != t {
+		return fmt.Errorf("dkg: public polynomial has %d coefficients instead of %d", len(commits), t)
+	}
+	minusOne := suite.Scalar().Neg(suite.Scalar().One())
+	null := suite.Point().Null()
+	for i, c := range commits {
+		if c == nil {
+			return fmt.Errorf("dkg: missing coefficient %d in the public polynomial", i)
+		}
+		q := suite.Point().Mul(minusOne, c)
+		if !q.Add(q, c).Equal(null) {
+			return fmt.Errorf("dkg: coefficient %d of the public polynomial not in the prime-order subgroup", i)
+		}
+	}
+	if commits[0].Equal(null) {
+		return errors.New("dkg: public key is the identity")
+	}
+	return nil
+}
+
+func equalQUAL(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func equalCommits(a, b []kyber.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type resultJSON struct {
+	Suite       string
+	QUAL        []int
+	Index       int
+	Share       string
+	Commits     []string
+	PrivatePoly []string `json:",omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The name of the suite is included
+// so that UnmarshalJSON can refuse a result produced with another suite.
+func (r *Result) MarshalJSON() ([]byte, error) {
+	if r.suite == nil || r.Key == nil || r.Key.Share == nil {
+		return nil, errors.New("dkg: incomplete result")
+	}
+	var err error
+	rj := &resultJSON{
+		Suite:       r.suite.String(),
+		QUAL:        r.QUAL,
+		Index:       r.Key.Share.I,
+		Commits:     make([]string, len(r.Key.Commits)),
+		PrivatePoly: make([]string, len(r.Key.PrivatePoly)),
+	}
+	if rj.Share, err = encoding.ScalarToStringHex(r.suite, r.Key.Share.V); err != nil {
+		return nil, err
+	}
+	for i, c := range r.Key.Commits {
+		if rj.Commits[i], err = encoding.PointToStringHex(r.suite, c); err != nil {
+			return nil, err
+		}
+	}
+	for i, c := range r.Key.PrivatePoly {
+		if rj.PrivatePoly[i], err = encoding.ScalarToStringHex(r.suite, c); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(rj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The receiver must have been
+// created with NewResult, and an error is returned if the data was encoded
+// with a different suite.
+func (r *Result) UnmarshalJSON(data []byte) error {
+	if r.suite == nil {
+		return errors.New("dkg: result must be created with NewResult")
+	}
+	rj := &resultJSON{}
+	if err := json.Unmarshal(data, rj); err != nil {
+		return err
+	}
+	if rj.Suite != r.suite.String() {
+		return fmt.Errorf("dkg: result of suite %s can not be loaded with suite %s", rj.Suite, r.suite.String())
+	}
+	v, err := encoding.StringHexToScalar(r.suite, rj.Share)
+	if err != nil {
+		return err
+	}
+	commits := make([]kyber.Point, len(rj.Commits))
+	for i, c := range rj.Commits {
+		if commits[i], err = encoding.StringHexToPoint(r.suite, c); err != nil {
+			return err
+		}
+	}
+	var priPoly []kyber.Scalar
+	if len(rj.PrivatePoly) > 0 {
+		priPoly = make([]kyber.Scalar, len(rj.PrivatePoly))
+		for i, c := range rj.PrivatePoly {
+			if priPoly[i], err = encoding.StringHexToScalar(r.suite, c); err != nil {
+				return err
+			}
+		}
+	}
+	r.QUAL = rj.QUAL
+	r.Key = &DistKeyShare{
+		Commits:     commits,
+		Share:       &share.PriShare{I: rj.Index, V: v},
+		PrivatePoly: priPoly,
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package dkg
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+func TestResultJSON(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	res, err := dkgs[1].Result()
+	require.NoError(t, err)
+	require.Equal(t, dkgs[1].QUAL(), res.QUAL)
+
+	buff, err := json.Marshal(res)
+	require.NoError(t, err)
+
+	loaded := NewResult(suite)
+	require.NoError(t, json.Unmarshal(buff, loaded))
+	require.Equal(t, res.QUAL, loaded.QUAL)
+	require.True(t, checkDks(res.Key, loaded.Key))
+	require.Equal(t, res.Key.Share.I, loaded.Key.Share.I)
+	require.True(t, res.Key.Share.V.Equal(loaded.Key.Share.V))
+	require.Len(t, loaded.Key.PrivatePoly, len(res.Key.PrivatePoly))
+	for i := range res.Key.PrivatePoly {
+		require.True(t, res.Key.PrivatePoly[i].Equal(loaded.Key.PrivatePoly[i]))
+	}
+
+	// a result can't be loaded with another suite
+	require.Error(t, json.Unmarshal(buff, NewResult(bn256.NewSuiteG2())))
+	// nor without any suite
+	require.Error(t, json.Unmarshal(buff, &Result{}))
+}
+
+func TestVerifyResults(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	results := make([]*Result, len(dkgs))
+	for i, d := range dkgs {
+		res, err := d.Result()
+		require.NoError(t, err)
+		results[i] = res
+	}
+	require.NoError(t, VerifyResults(results))
+	require.Error(t, VerifyResults(nil))
+
+	// a tampered private share is detected
+	good := results[2].Key.Share.V
+	results[2].Key.Share.V = suite.Scalar().Add(good, suite.Scalar().One())
+	require.Error(t, VerifyResults(results))
+	results[2].Key.Share.V = good
+
+	// so is a diverging public polynomial
+	goodCommit := results[3].Key.Commits[1]
+	results[3].Key.Commits[1] = suite.Point().Add(goodCommit, suite.Point().Base())
+	require.Error(t, VerifyResults(results))
+	results[3].Key.Commits[1] = goodCommit
+
+	// and a diverging QUAL set
+	results[4].QUAL = results[4].QUAL[1:]
+	require.Error(t, VerifyResults(results))
+}
+
+func TestVerifyPublicResult(t *testing.T) {
+	pubs, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+	dks, err := dkgs[0].DistKeyShare()
+	require.NoError(t, err)
+	pub := share.NewPubPoly(suite, nil, dks.Commits)
+	require.NoError(t, VerifyPublicResult(suite, pub, pubs, defaultT))
+
+	// a polynomial of the wrong degree
+	extra := append(append([]kyber.Point{}, dks.Commits...), suite.Point().Base())
+	require.Error(t, VerifyPublicResult(suite, share.NewPubPoly(suite, nil, extra), pubs, defaultT))
+	require.Error(t, VerifyPublicResult(suite, share.NewPubPoly(suite, nil, dks.Commits[1:]), pubs, defaultT))
+	require.Error(t, VerifyPublicResult(suite, pub, pubs, defaultT+1))
+
+	// an identity public key
+	null := append([]kyber.Point{suite.Point().Null()}, dks.Commits[1:]...)
+	require.Error(t, VerifyPublicResult(suite, share.NewPubPoly(suite, nil, null), pubs, defaultT))
+
+	// another base point
+	other := share.NewPubPoly(suite, suite.Point().Pick(suite.RandomStream()), dks.Commits)
+	require.Error(t, VerifyPublicResult(suite, other, pubs, defaultT))
+
+	// duplicate nodes
+	dup := append(append([]kyber.Point{}, pubs...), pubs[0])
+	require.Error(t, VerifyPublicResult(suite, pub, dup, defaultT))
+}
+
+func TestResultVerifyPubShares(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	pubShares := make([]*share.PubShare, len(dkgs))
+	for i, d := range dkgs {
+		dks, err := d.DistKeyShare()
+		require.NoError(t, err)
+		pubShares[i] = &share.PubShare{I: dks.Share.I, V: suite.Point().Mul(dks.Share.V, nil)}
+	}
+	res, err := dkgs[0].Result()
+	require.NoError(t, err)
+
+	// every subset of t public shares recovers the same key
+	for i := 0; i+defaultT <= len(pubShares); i++ {
+		require.NoError(t, res.VerifyPubShares(pubShares[i:i+defaultT], defaultT, defaultN))
+	}
+	require.NoError(t, res.VerifyPubShares(pubShares, defaultT, defaultN))
+	require.Error(t, res.VerifyPubShares(pubShares[:defaultT-1], defaultT, defaultN))
+
+	tampered := append([]*share.PubShare{}, pubShares[:defaultT]...)
+	tampered[0] = &share.PubShare{I: tampered[0].I, V: suite.Point().Add(tampered[0].V, suite.Point().Base())}
+	require.Error(t, res.VerifyPubShares(tampered, defaultT, defaultN))
+}
+
+func TestResultShareProof(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	res, err := dkgs[1].Result()
+	require.NoError(t, err)
+	pubPoly := share.NewPubPoly(suite, nil, res.Key.Commits)
+	pubShare := pubPoly.Eval(res.Key.Share.I)
+	challenge := []byte("fresh challenge")
+
+	proof, err := res.ProveShare(challenge)
+	require.NoError(t, err)
+	require.NoError(t, VerifyShareProof(suite, pubShare, challenge, proof))
+
+	// the proof is bound to the challenge and to the index of the prover
+	require.Error(t, VerifyShareProof(suite, pubShare, []byte("old challenge"), proof))
+	require.Error(t, VerifyShareProof(suite, pubPoly.Eval(2), challenge, proof))
+
+	// a forged proof made with another scalar does not verify
+	forged := &Result{QUAL: res.QUAL, suite: suite, Key: &DistKeyShare{
+		Commits: res.Key.Commits,
+		Share:   &share.PriShare{I: res.Key.Share.I, V: suite.Scalar().Pick(suite.RandomStream())},
+	}}
+	proof, err = forged.ProveShare(challenge)
+	require.NoError(t, err)
+	require.Error(t, VerifyShareProof(suite, pubShare, challenge, proof))
+
+	require.Error(t, VerifyShareProof(suite, nil, challenge, proof))
+	_, err = NewResult(suite).ProveShare(challenge)
+	require.Error(t, err)
+}
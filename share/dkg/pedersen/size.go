@@ -0,0 +1,58 @@
+package dkg
+
+import (
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+// EstimateMessageSizes returns the size in bytes of the messages a
+// DistKeyGenerator created from c exchanges: a deal as encoded by
+// PackDeals without the count of deals, a response and a justification as
+// their index followed by the Encode encoding of the vss message.
+// They depend on the lengths of the points, scalars and hashes of the suite
+// and on the threshold, which is the number of commitments in a deal. The
+// vss deal encrypted in a deal is encoded with protobuf, whose varints make
+// its size grow slightly with the index of the recipient: the size returned
+// is the one of the deal to the last node. In a DKG of n nodes, every node
+// sends n-1 deals and broadcasts n-1 responses, and justifications are only
+// sent after complaints.
+func EstimateMessageSizes(c *Config) (deal, response, justification int) {
+	pointLen := c.Suite.PointLen()
+	scalarLen := c.Suite.ScalarLen()
+	hashLen := c.Suite.Hash().Size()
+	t := c.Threshold
+	if t == 0 {
+		t = vss.MinimumT(len(c.NewNodes))
+	}
+	// a schnorr signature is a point and a scalar
+	sigLen := pointLen + scalarLen
+	// session ID, share index and value, threshold and commitments, in the
+	// encoding of Deal.Encode and in the one of protobuf, where a field is
+	// a one-byte tag followed by a varint or by a length and the bytes, and
+	// the share index is a zigzag varint
+	vssDeal := 4 + hashLen + 4 + scalarLen + 4 + 4 + t*pointLen
+	lastIndex := uint64(len(c.NewNodes)-1) << 1
+	priShare := (1 + varintLen(lastIndex)) + protoBytesLen(scalarLen)
+	protoDeal := protoBytesLen(hashLen) + protoBytesLen(priShare) +
+		(1 + varintLen(uint64(t))) + t*protoBytesLen(pointLen)
+
+	const nonceLen, tagLen = 12, 16
+	deal = 4 + (4 + hashLen) + (4 + pointLen) + (4 + sigLen) + (4 + nonceLen) +
+		(4 + protoDeal + tagLen) + (4 + sigLen)
+	response = 4 + (4 + hashLen) + 4 + 1 + (4 + sigLen)
+	justification = 4 + (4 + hashLen) + 4 + (4 + vssDeal) + (4 + sigLen)
+	return deal, response, justification
+}
+
+// protoBytesLen returns the length of a field of l bytes in protobuf.
+func protoBytesLen(l int) int {
+	return 1 + varintLen(uint64(l)) + l
+}
+
+func varintLen(v uint64) int {
+	n := 1
+	for v >= 0x80 {
+		v >>= 7
+		n++
+	}
+	return n
+}
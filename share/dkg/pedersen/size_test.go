@@ -0,0 +1,43 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+func TestEstimateMessageSizes(t *testing.T) {
+	for _, threshold := range []int{defaultT, defaultN} {
+		pubs, privs, dkgs := generate(defaultN, threshold)
+		deal, response, justification := EstimateMessageSizes(&Config{
+			Suite:     suite,
+			Longterm:  privs[0],
+			NewNodes:  pubs,
+			Threshold: threshold,
+		})
+
+		deals, err := dkgs[0].Deals()
+		require.NoError(t, err)
+		last := deals[defaultN-1]
+		buf, err := PackDeals([]*Deal{last})
+		require.NoError(t, err)
+		require.Equal(t, len(buf)-4, deal)
+
+		resp, err := dkgs[defaultN-1].ProcessDeal(last)
+		require.NoError(t, err)
+		buf, err = resp.Response.Encode()
+		require.NoError(t, err)
+		require.Equal(t, 4+len(buf), response)
+
+		plain, err := dkgs[0].dealer.PlaintextDeal(1)
+		require.NoError(t, err)
+		j := &vss.Justification{SessionID: plain.SessionID, Index: 1, Deal: plain}
+		j.Signature, err = schnorr.Sign(suite, privs[0], j.Hash(suite))
+		require.NoError(t, err)
+		buf, err = j.Encode()
+		require.NoError(t, err)
+		require.Equal(t, 4+len(buf), justification)
+	}
+}
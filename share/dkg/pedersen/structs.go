@@ -43,6 +43,8 @@ func (d *DistKeyShare) Commitments() []kyber.Point {
 type Deal struct {
 	// Index of the Dealer in the list of participants
 	Index uint32
+	// SessionID of the Config of the DKG, see Config.SessionID
+	SessionID []byte
 	// Deal issued for another participant
 	Deal *vss.EncryptedDeal
 	// Signature over the whole message
@@ -54,6 +56,8 @@ type Deal struct {
 func (d *Deal) MarshalBinary() ([]byte, error) {
 	var b bytes.Buffer
 	binary.Write(&b, binary.LittleEndian, d.Index)
+	binary.Write(&b, binary.LittleEndian, uint32(len(d.SessionID)))
+	b.Write(d.SessionID)
 	b.Write(d.Deal.Cipher)
 	return b.Bytes(), nil
 }
@@ -0,0 +1,109 @@
+package dkg
+
+import (
+	"sync"
+
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+// SyncGenerator wraps a DistKeyGenerator so that it can be used from
+// multiple goroutines, for example when each incoming network message is
+// handled in its own goroutine. Every call is serialized through a mutex, so
+// the order in which concurrent messages are processed is the order in which
+// they acquire the lock.
+type SyncGenerator struct {
+	m sync.Mutex
+	d *DistKeyGenerator
+}
+
+// NewSyncGenerator returns a SyncGenerator wrapping d. The caller must not
+// use d directly anymore once it has been wrapped.
+func NewSyncGenerator(d *DistKeyGenerator) *SyncGenerator {
+	return &SyncGenerator{d: d}
+}
+
+// Deals calls DistKeyGenerator.Deals.
+func (s *SyncGenerator) Deals() (map[int]*Deal, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.Deals()
+}
+
+// ProcessDeal calls DistKeyGenerator.ProcessDeal.
+func (s *SyncGenerator) ProcessDeal(dd *Deal) (*Response, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.ProcessDeal(dd)
+}
+
+// ProcessDeals calls DistKeyGenerator.ProcessDeals.
+func (s *SyncGenerator) ProcessDeals(deals []*Deal) ([]*Response, []error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.ProcessDeals(deals)
+}
+
+// ProcessResponse calls DistKeyGenerator.ProcessResponse.
+func (s *SyncGenerator) ProcessResponse(resp *Response) (*Justification, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.ProcessResponse(resp)
+}
+
+// ProcessJustification calls DistKeyGenerator.ProcessJustification.
+func (s *SyncGenerator) ProcessJustification(j *Justification) error {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.ProcessJustification(j)
+}
+
+// SetTimeout calls DistKeyGenerator.SetTimeout.
+func (s *SyncGenerator) SetTimeout() {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.d.SetTimeout()
+}
+
+// Certified calls DistKeyGenerator.Certified.
+func (s *SyncGenerator) Certified() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.Certified()
+}
+
+// ThresholdCertified calls DistKeyGenerator.ThresholdCertified.
+func (s *SyncGenerator) ThresholdCertified() bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.ThresholdCertified()
+}
+
+// QUAL calls DistKeyGenerator.QUAL.
+func (s *SyncGenerator) QUAL() []int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.QUAL()
+}
+
+// QualifiedShares calls DistKeyGenerator.QualifiedShares.
+func (s *SyncGenerator) QualifiedShares() []int {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.QualifiedShares()
+}
+
+// DistKeyShare calls DistKeyGenerator.DistKeyShare.
+func (s *SyncGenerator) DistKeyShare() (*DistKeyShare, error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.DistKeyShare()
+}
+
+// Verifiers calls DistKeyGenerator.Verifiers. The returned verifiers are not
+// protected by the lock and must not be modified concurrently with other
+// calls on s.
+func (s *SyncGenerator) Verifiers() map[uint32]*vss.Verifier {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.d.Verifiers()
+}
@@ -0,0 +1,84 @@
+package dkg
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+func TestSyncGeneratorConcurrent(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	syncs := make([]*SyncGenerator, len(dkgs))
+	for i, d := range dkgs {
+		syncs[i] = NewSyncGenerator(d)
+	}
+
+	// 1. every dealer sends its deals concurrently; the goroutines report
+	// to the test goroutine, which alone may fail the test
+	type result struct {
+		resp *Response
+		err  error
+	}
+	var wg sync.WaitGroup
+	results := make(chan result, defaultN*defaultN)
+	for _, s := range syncs {
+		deals, err := s.Deals()
+		require.NoError(t, err)
+		for i, d := range deals {
+			wg.Add(1)
+			go func(s *SyncGenerator, d *Deal) {
+				defer wg.Done()
+				resp, err := s.ProcessDeal(d)
+				results <- result{resp, err}
+			}(syncs[i], d)
+		}
+	}
+	wg.Wait()
+	close(results)
+	var resps []*Response
+	for r := range results {
+		require.NoError(t, r.err)
+		require.Equal(t, vss.StatusApproval, r.resp.Response.Status)
+		resps = append(resps, r.resp)
+	}
+
+	// 2. responses are broadcast concurrently while others poll the state
+	errs := make(chan error, len(resps)*len(syncs))
+	for _, resp := range resps {
+		for i, s := range syncs {
+			if resp.Response.Index == uint32(dkgs[i].nidx) {
+				continue
+			}
+			wg.Add(2)
+			go func(s *SyncGenerator, resp *Response) {
+				defer wg.Done()
+				j, err := s.ProcessResponse(resp)
+				if err == nil && j != nil {
+					err = errors.New("unexpected justification")
+				}
+				errs <- err
+			}(s, resp)
+			go func(s *SyncGenerator) {
+				defer wg.Done()
+				s.Certified()
+				s.QUAL()
+			}(s)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	for _, s := range syncs {
+		require.True(t, s.Certified())
+		require.Len(t, s.QUAL(), defaultN)
+		dks, err := s.DistKeyShare()
+		require.NoError(t, err)
+		require.NotNil(t, dks)
+	}
+}
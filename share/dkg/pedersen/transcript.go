@@ -0,0 +1,385 @@
+package dkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+// Transcript is a public record of a fresh DKG, from which an auditor that
+// did not take part can check, with VerifyTranscript, that the distributed
+// public key comes from dealers that the participants qualified. It holds
+// no secret. The auditor must compare Public to the key it knows the group
+// by: a transcript only proves how its own Public was produced, and that at
+// least Threshold participants signed it.
+type Transcript struct {
+	// Threshold of the DKG.
+	Threshold int
+	// Timeout tells whether the node building the transcript had called
+	// SetTimeout, which allows missing responses.
+	Timeout bool
+	// Deals holds the public part of the deal of every dealer of QUAL.
+	Deals []*TranscriptDeal
+	// QUAL is the list of qualified dealers.
+	QUAL []int
+	// Public is the distributed public key.
+	Public kyber.Point
+	// Signatures of the participants over the hash of the transcript.
+	Signatures []*TranscriptSignature
+}
+
+// TranscriptSignature is the signature of a participant over the hash of a
+// transcript, as returned by Transcript.Hash.
+type TranscriptSignature struct {
+	// Index of the participant.
+	Index uint32
+	// Signature over the hash of the transcript.
+	Signature []byte
+}
+
+// TranscriptDeal holds the commitments of a dealer together with the signed
+// responses of the participants and the justifications of the dealer.
+type TranscriptDeal struct {
+	// Index of the dealer.
+	Index uint32
+	// Commits are the public commitments of the polynomial of the dealer.
+	Commits []kyber.Point
+	// Responses to the deal, sorted by the index of the participant. The
+	// dealer does not respond to its own deal.
+	Responses []*vss.Response
+	// Justifications of the dealer for the complaints among Responses.
+	Justifications []*vss.Justification
+}
+
+// Transcript returns the transcript of this DKG, signed by this node. It
+// must be called once the DKG is certified, and only for a fresh DKG, not for
+// a resharing. The other participants add their signatures with
+// SignTranscript.
+func (d *DistKeyGenerator) Transcript() (*Transcript, error) {
+	if d.isResharing {
+		return nil, errors.New("dkg: no transcript for a resharing")
+	}
+	dks, err := d.DistKeyShare()
+	if err != nil {
+		return nil, err
+	}
+
+	tr := &Transcript{
+		Threshold: d.newT,
+		Timeout:   d.timeout,
+		QUAL:      d.QUAL(),
+		Public:    dks.Public(),
+	}
+	for _, i := range tr.QUAL {
+		v := d.verifiers[uint32(i)]
+		justifications := d.justifications[uint32(i)]
+		td := &TranscriptDeal{
+			Index:          uint32(i),
+			Commits:        v.Commits(),
+			Justifications: justifications,
+		}
+		for _, r := range v.Responses() {
+			// the approval of the dealer for its own deal is implicit
+			if r.Index == uint32(i) {
+				continue
+			}
+			// a justified complaint has been turned into an approval, restore
+			// the status it was signed with
+			c := *r
+			for _, j := range justifications {
+				if j.Index == r.Index {
+					c.Status = vss.StatusComplaint
+				}
+			}
+			td.Responses = append(td.Responses, &c)
+		}
+		sort.Slice(td.Responses, func(a, b int) bool {
+			return td.Responses[a].Index < td.Responses[b].Index
+		})
+		tr.Deals = append(tr.Deals, td)
+	}
+	sig, err := d.SignTranscript(tr)
+	if err != nil {
+		return nil, err
+	}
+	tr.Signatures = append(tr.Signatures, sig)
+	return tr, nil
+}
+
+// SignTranscript returns the signature of this node over the hash of the
+// transcript, to be appended to its Signatures. It returns an error if the
+// threshold, QUAL or the public key of the transcript differ from the ones
+// of this node, or if the transcript tells of a timeout this node did not
+// reach.
+func (d *DistKeyGenerator) SignTranscript(tr *Transcript) (*TranscriptSignature, error) {
+	if d.isResharing {
+		return nil, errors.New("dkg: no transcript for a resharing")
+	}
+	dks, err := d.DistKeyShare()
+	if err != nil {
+		return nil, err
+	}
+	if tr.Threshold != d.newT || !equalQUAL(tr.QUAL, d.QUAL()) ||
+		tr.Public == nil || !tr.Public.Equal(dks.Public()) {
+		return nil, errors.New("dkg: transcript does not match this DKG")
+	}
+	if tr.Timeout && !d.timeout {
+		return nil, errors.New("dkg: transcript after a timeout this node did not reach")
+	}
+	h, err := tr.Hash(d.suite)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := schnorr.Sign(d.suite, d.long, h)
+	if err != nil {
+		return nil, err
+	}
+	return &TranscriptSignature{Index: uint32(d.nidx), Signature: sig}, nil
+}
+
+// Hash returns the hash that the participants sign: the hash of the
+// threshold, the timeout, QUAL, the public key and of every deal with its
+// responses and justifications, but not of the signatures.
+func (tr *Transcript) Hash(s Suite) ([]byte, error) {
+	if tr.Public == nil {
+		return nil, errors.New("dkg: transcript without public key")
+	}
+	h := s.Hash()
+	_, _ = h.Write([]byte("dkg signed transcript"))
+	_ = binary.Write(h, binary.LittleEndian, uint32(tr.Threshold))
+	_ = binary.Write(h, binary.LittleEndian, tr.Timeout)
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(tr.QUAL)))
+	for _, i := range tr.QUAL {
+		_ = binary.Write(h, binary.LittleEndian, uint32(i))
+	}
+	if _, err := tr.Public.MarshalTo(h); err != nil {
+		return nil, err
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(tr.Deals)))
+	for _, td := range tr.Deals {
+		if td == nil {
+			return nil, errors.New("dkg: nil deal in transcript")
+		}
+		_ = binary.Write(h, binary.LittleEndian, td.Index)
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(td.Commits)))
+		for _, c := range td.Commits {
+			if c == nil {
+				return nil, errors.New("dkg: nil commitment in transcript")
+			}
+			if _, err := c.MarshalTo(h); err != nil {
+				return nil, err
+			}
+		}
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(td.Responses)))
+		for _, r := range td.Responses {
+			if r == nil {
+				return nil, errors.New("dkg: nil response in transcript")
+			}
+			_, _ = h.Write(r.Hash(s))
+		}
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(td.Justifications)))
+		for _, j := range td.Justifications {
+			if j == nil {
+				return nil, errors.New("dkg: nil justification in transcript")
+			}
+			_, _ = h.Write(j.Hash(s))
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// TranscriptHash returns a hash of the public part of the messages this
+// generator has accepted so far, in a canonical order: for every dealer by
+// increasing index, the commitments of its deal, the responses to it sorted
+// by participant index, without the implicit approval of the dealer, and
+// the justifications of the dealer sorted by participant index. Nodes that
+// accepted the same messages compute the same hash, so comparing it at the
+// end of the DKG detects a dealer that sent different commitments to
+// different nodes.
+func (d *DistKeyGenerator) TranscriptHash() []byte {
+	h := d.suite.Hash()
+	_, _ = h.Write([]byte("dkg transcript"))
+	dealers := make([]int, 0, len(d.verifiers))
+	for i := range d.verifiers {
+		dealers = append(dealers, int(i))
+	}
+	sort.Ints(dealers)
+	for _, i := range dealers {
+		v := d.verifiers[uint32(i)]
+		commits := v.Commits()
+		if commits == nil {
+			continue
+		}
+		_ = binary.Write(h, binary.LittleEndian, uint32(i))
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(commits)))
+		for _, c := range commits {
+			_, _ = c.MarshalTo(h)
+		}
+
+		var resps []*vss.Response
+		for _, r := range v.Responses() {
+			if r.Index != uint32(i) {
+				resps = append(resps, r)
+			}
+		}
+		sort.Slice(resps, func(a, b int) bool {
+			return resps[a].Index < resps[b].Index
+		})
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(resps)))
+		for _, r := range resps {
+			_, _ = h.Write(r.Hash(d.suite))
+		}
+
+		justs := append([]*vss.Justification{}, d.justifications[uint32(i)]...)
+		sort.Slice(justs, func(a, b int) bool {
+			return justs[a].Index < justs[b].Index
+		})
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(justs)))
+		for _, j := range justs {
+			_, _ = h.Write(j.Hash(d.suite))
+		}
+	}
+	return h.Sum(nil)
+}
+
+// DetectEquivocation returns true if the justifications a and b come from
+// the same dealer but reveal deals with different commitments, i.e. the
+// dealer sent inconsistent polynomials to different recipients. Since a
+// dealer signs its justifications, two justifications accepted by
+// ProcessJustification for which it returns true are a proof of the
+// misbehaviour that anyone can check.
+func DetectEquivocation(a, b *Justification) bool {
+	if a == nil || b == nil || a.Index != b.Index {
+		return false
+	}
+	ja, jb := a.Justification, b.Justification
+	if ja == nil || jb == nil || ja.Deal == nil || jb.Deal == nil {
+		return false
+	}
+	ca, cb := ja.Deal.Commitments, jb.Deal.Commitments
+	if len(ca) != len(cb) {
+		return true
+	}
+	for i := range ca {
+		if !ca[i].Equal(cb[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyTranscript checks that at least Threshold distinct nodes signed the
+// transcript, then checks the signatures of all the responses and
+// justifications of the transcript against the public keys of the nodes,
+// recomputes QUAL from them in the same way as the participants, and checks
+// that it matches the transcript's QUAL and that the public key is the sum
+// of the secrets committed to by the dealers of QUAL.
+func VerifyTranscript(suite Suite, tr *Transcript, nodes []kyber.Point) error {
+	if tr.Threshold < 1 || tr.Threshold > len(nodes) {
+		return errors.New("dkg: invalid threshold in transcript")
+	}
+	h, err := tr.Hash(suite)
+	if err != nil {
+		return err
+	}
+	signers := make(map[uint32]bool)
+	for _, sig := range tr.Signatures {
+		if sig == nil || int(sig.Index) >= len(nodes) || signers[sig.Index] {
+			continue
+		}
+		if schnorr.Verify(suite, nodes[sig.Index], h, sig.Signature) == nil {
+			signers[sig.Index] = true
+		}
+	}
+	if len(signers) < tr.Threshold {
+		return fmt.Errorf("dkg: transcript signed by %d nodes, need %d", len(signers), tr.Threshold)
+	}
+
+	var qual []int
+	public := suite.Point().Null()
+	seen := make(map[uint32]bool)
+	for _, td := range tr.Deals {
+		if int(td.Index) >= len(nodes) || seen[td.Index] {
+			return fmt.Errorf("dkg: invalid dealer index %d in transcript", td.Index)
+		}
+		seen[td.Index] = true
+		qualified, err := verifyTranscriptDeal(suite, tr, td, nodes)
+		if err != nil {
+			return err
+		}
+		if qualified {
+			qual = append(qual, int(td.Index))
+			public.Add(public, td.Commits[0])
+		}
+	}
+
+	sort.Ints(qual)
+	if !equalQUAL(qual, tr.QUAL) {
+		return errors.New("dkg: transcript QUAL does not match its responses")
+	}
+	if tr.Public == nil || !public.Equal(tr.Public) {
+		return errors.New("dkg: transcript public key does not match the commitments")
+	}
+	return nil
+}
+
+// verifyTranscriptDeal returns an error if a response or justification of
+// the deal is not authentic, and otherwise whether the deal is certified
+// according to the same rules as vss.Aggregator.DealCertified.
+func verifyTranscriptDeal(suite Suite, tr *Transcript, td *TranscriptDeal, nodes []kyber.Point) (bool, error) {
+	if len(td.Commits) != tr.Threshold {
+		return false, nil
+	}
+	dealer := nodes[td.Index]
+	sid, err := vss.SessionID(suite, dealer, nodes, td.Commits, tr.Threshold)
+	if err != nil {
+		return false, err
+	}
+	pubPoly := share.NewPubPoly(suite, suite.Point().Base(), td.Commits)
+
+	justified := make(map[uint32]bool)
+	for _, j := range td.Justifications {
+		if !bytes.Equal(j.SessionID, sid) || int(j.Index) >= len(nodes) || j.Deal == nil {
+			return false, errors.New("dkg: invalid justification in transcript")
+		}
+		if err := schnorr.Verify(suite, dealer, j.Hash(suite), j.Signature); err != nil {
+			return false, err
+		}
+		// a justification revealing a bad share disqualifies the dealer
+		sh := j.Deal.SecShare
+		if sh == nil || sh.I != int(j.Index) || !pubPoly.Check(sh) {
+			return false, nil
+		}
+		justified[j.Index] = true
+	}
+
+	// the dealer implicitly approves its own deal
+	approvals := 1
+	responded := map[uint32]bool{td.Index: true}
+	for _, r := range td.Responses {
+		if !bytes.Equal(r.SessionID, sid) || int(r.Index) >= len(nodes) || responded[r.Index] {
+			return false, errors.New("dkg: invalid response in transcript")
+		}
+		responded[r.Index] = true
+		if err := schnorr.Verify(suite, nodes[r.Index], r.Hash(suite), r.Signature); err != nil {
+			return false, err
+		}
+		if r.Status == vss.StatusComplaint && !justified[r.Index] {
+			return false, nil
+		}
+		approvals++
+	}
+
+	absents := len(nodes) - len(responded)
+	if tr.Timeout {
+		return approvals >= tr.Threshold && absents <= len(nodes)-tr.Threshold, nil
+	}
+	return approvals >= tr.Threshold && absents == 0, nil
+}
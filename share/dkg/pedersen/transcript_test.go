@@ -0,0 +1,267 @@
+package dkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	vss "go.dedis.ch/kyber/v3/share/vss/pedersen"
+)
+
+// signTranscript replaces the signatures of tr with the ones of the
+// generators.
+func signTranscript(t *testing.T, tr *Transcript, dkgs []*DistKeyGenerator) {
+	tr.Signatures = nil
+	for _, dkg := range dkgs {
+		sig, err := dkg.SignTranscript(tr)
+		require.NoError(t, err)
+		tr.Signatures = append(tr.Signatures, sig)
+	}
+}
+
+func TestDKGTranscript(t *testing.T) {
+	pubs, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	tr, err := dkgs[0].Transcript()
+	require.NoError(t, err)
+	require.Len(t, tr.Deals, defaultN)
+	require.Len(t, tr.Signatures, 1)
+	// the signature of the builder alone is not enough
+	require.Error(t, VerifyTranscript(suite, tr, pubs))
+	signTranscript(t, tr, dkgs[:defaultT])
+	require.NoError(t, VerifyTranscript(suite, tr, pubs))
+	// nor are repeated signatures
+	tr.Signatures = append(tr.Signatures[:defaultT-1], tr.Signatures[0])
+	require.Error(t, VerifyTranscript(suite, tr, pubs))
+	signTranscript(t, tr, dkgs)
+	dks, err := dkgs[1].DistKeyShare()
+	require.NoError(t, err)
+	require.True(t, tr.Public.Equal(dks.Public()))
+
+	// all the nodes produce a valid transcript of the same key
+	tr2, err := dkgs[2].Transcript()
+	require.NoError(t, err)
+	signTranscript(t, tr2, dkgs)
+	require.NoError(t, VerifyTranscript(suite, tr2, pubs))
+	require.True(t, tr.Public.Equal(tr2.Public))
+
+	// one deal removed
+	removed := *tr
+	removed.Deals = tr.Deals[1:]
+	require.Error(t, VerifyTranscript(suite, &removed, pubs))
+	// even with QUAL and the key adjusted accordingly, since the
+	// participants did not sign it
+	removed.QUAL = tr.QUAL[1:]
+	removed.Public = suite.Point().Sub(tr.Public, tr.Deals[0].Commits[0])
+	require.Error(t, VerifyTranscript(suite, &removed, pubs))
+	// and refuse to
+	_, err = dkgs[1].SignTranscript(&removed)
+	require.Error(t, err)
+
+	// one response removed
+	td := *tr.Deals[2]
+	td.Responses = td.Responses[1:]
+	missing := *tr
+	missing.Deals = append([]*TranscriptDeal{}, tr.Deals...)
+	missing.Deals[2] = &td
+	signTranscript(t, &missing, dkgs)
+	require.Error(t, VerifyTranscript(suite, &missing, pubs))
+	// which is allowed after a timeout, if the participants reached it
+	missing.Timeout = true
+	require.Error(t, VerifyTranscript(suite, &missing, pubs))
+	_, err = dkgs[1].SignTranscript(&missing)
+	require.Error(t, err)
+	for _, dkg := range dkgs {
+		dkg.SetTimeout()
+	}
+	signTranscript(t, &missing, dkgs)
+	require.NoError(t, VerifyTranscript(suite, &missing, pubs))
+
+	// a forged complaint
+	forged := *tr
+	forged.Deals = append([]*TranscriptDeal{}, tr.Deals...)
+	td = *tr.Deals[3]
+	td.Responses = append([]*vss.Response{}, td.Responses...)
+	r := *td.Responses[0]
+	r.Status = vss.StatusComplaint
+	td.Responses[0] = &r
+	forged.Deals[3] = &td
+	signTranscript(t, &forged, dkgs)
+	require.Error(t, VerifyTranscript(suite, &forged, pubs))
+
+	// a wrong key
+	wrong := *tr
+	wrong.Public = suite.Point().Base()
+	require.Error(t, VerifyTranscript(suite, &wrong, pubs))
+}
+
+func TestDKGTranscriptJustification(t *testing.T) {
+	pubs, _, dkgs := generate(defaultN, defaultT)
+
+	// dealer 0 sends a bad share to node 1
+	deal, err := dkgs[0].dealer.PlaintextDeal(1)
+	require.NoError(t, err)
+	goodSecret := deal.SecShare.V
+	deal.SecShare.V = suite.Scalar().Zero()
+	var resps []*Response
+	for i, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		if i == 0 {
+			deal.SecShare.V = goodSecret
+		}
+		for j, d := range deals {
+			resp, err := dkgs[j].ProcessDeal(d)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+	}
+
+	// every node gets its own copy of each response, since processing a
+	// complaint modifies it
+	var justifs []*Justification
+	for _, resp := range resps {
+		for _, dkg := range dkgs {
+			if resp.Response.Index == uint32(dkg.nidx) {
+				continue
+			}
+			r := *resp.Response
+			j, err := dkg.ProcessResponse(&Response{Index: resp.Index, Response: &r})
+			require.NoError(t, err)
+			if j != nil {
+				justifs = append(justifs, j)
+			}
+		}
+	}
+	require.Len(t, justifs, 1)
+	for _, dkg := range dkgs[1:] {
+		require.NoError(t, dkg.ProcessJustification(justifs[0]))
+		require.True(t, dkg.Certified())
+	}
+
+	tr, err := dkgs[2].Transcript()
+	require.NoError(t, err)
+	require.Len(t, tr.Deals[0].Justifications, 1)
+	signTranscript(t, tr, dkgs)
+	require.NoError(t, VerifyTranscript(suite, tr, pubs))
+
+	// without the justification, the complaint disqualifies dealer 0
+	td := *tr.Deals[0]
+	td.Justifications = nil
+	tr.Deals[0] = &td
+	signTranscript(t, tr, dkgs)
+	require.Error(t, VerifyTranscript(suite, tr, pubs))
+}
+
+func TestDKGTranscriptHash(t *testing.T) {
+	_, _, dkgs := generate(defaultN, defaultT)
+	fullExchange(t, dkgs, true)
+
+	h := dkgs[0].TranscriptHash()
+	for _, dkg := range dkgs[1:] {
+		require.Equal(t, h, dkg.TranscriptHash())
+	}
+
+	// dealer 0 equivocates: node 2 receives a deal of another polynomial
+	pubs, privs, dkgs := generate(defaultN, defaultT)
+	evil, err := NewDistKeyGenerator(suite, privs[0], pubs, defaultT)
+	require.NoError(t, err)
+	evilDeals, err := evil.Deals()
+	require.NoError(t, err)
+	var resps []*Response
+	for i, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		if i == 0 {
+			deals[2] = evilDeals[2]
+		}
+		for j, d := range deals {
+			resp, err := dkgs[j].ProcessDeal(d)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+	}
+	for _, resp := range resps {
+		for _, dkg := range dkgs {
+			if resp.Response.Index == uint32(dkg.nidx) {
+				continue
+			}
+			// the response of node 2 to dealer 0 is rejected by the others
+			_, _ = dkg.ProcessResponse(resp)
+		}
+	}
+	require.Equal(t, dkgs[1].TranscriptHash(), dkgs[3].TranscriptHash())
+	require.NotEqual(t, dkgs[1].TranscriptHash(), dkgs[2].TranscriptHash())
+}
+
+func TestDKGTranscriptOfflineDealer(t *testing.T) {
+	pubs, _, dkgs := generate(defaultN, defaultT)
+
+	// the deals of dealer 0 are never delivered
+	var resps []*Response
+	for i, dkg := range dkgs {
+		deals, err := dkg.Deals()
+		require.NoError(t, err)
+		if i == 0 {
+			continue
+		}
+		for j, d := range deals {
+			resp, err := dkgs[j].ProcessDeal(d)
+			require.NoError(t, err)
+			resps = append(resps, resp)
+		}
+	}
+	for _, resp := range resps {
+		for _, dkg := range dkgs {
+			if resp.Response.Index != uint32(dkg.nidx) {
+				_, err := dkg.ProcessResponse(resp)
+				require.NoError(t, err)
+			}
+		}
+	}
+	online := dkgs[1:]
+	for _, dkg := range online {
+		dkg.SetTimeout()
+	}
+
+	h := online[0].TranscriptHash()
+	for _, dkg := range online[1:] {
+		require.Equal(t, h, dkg.TranscriptHash())
+	}
+	tr, err := online[0].Transcript()
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3, 4}, tr.QUAL)
+	signTranscript(t, tr, online)
+	require.NoError(t, VerifyTranscript(suite, tr, pubs))
+}
+
+func TestDKGDetectEquivocation(t *testing.T) {
+	pubs, privs, dkgs := generate(defaultN, defaultT)
+	// a second generator with the key of dealer 0 deals another polynomial
+	evil, err := NewDistKeyGenerator(suite, privs[0], pubs, defaultT)
+	require.NoError(t, err)
+
+	justif := func(d *DistKeyGenerator, dealer, i int) *Justification {
+		deal, err := d.dealer.PlaintextDeal(i)
+		require.NoError(t, err)
+		return &Justification{
+			Index: uint32(dealer),
+			Justification: &vss.Justification{
+				SessionID: deal.SessionID,
+				Index:     uint32(i),
+				Deal:      deal,
+			},
+		}
+	}
+	j1 := justif(dkgs[0], 0, 1)
+	j2 := justif(dkgs[0], 0, 2)
+	require.False(t, DetectEquivocation(j1, j2))
+
+	evilJ2 := justif(evil, 0, 2)
+	require.True(t, DetectEquivocation(j1, evilJ2))
+
+	// justifications of different dealers are not compared
+	other := justif(dkgs[1], 1, 2)
+	require.False(t, DetectEquivocation(j1, other))
+	require.False(t, DetectEquivocation(j1, nil))
+}
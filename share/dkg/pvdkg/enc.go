@@ -0,0 +1,205 @@
+package pvdkg
+
+import (
+	"crypto/subtle"
+	"encoding/binary"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/proof/dleq"
+)
+
+// EncShare is the share f(i) of node i encrypted to its public key X: every
+// bit b_k of f(i) is encrypted with ElGamal as (R_k, C_k) = (r_k*G,
+// b_k*G + r_k*X), with a proof that it is a bit. Sum proves that the bits
+// are the ones of the discrete logarithm of F(i), the evaluation at i of the
+// polynomial committed to by the dealer, by showing that sum 2^k*R_k and
+// sum 2^k*C_k - F(i) have the same discrete logarithm with respect to G and
+// X.
+type EncShare struct {
+	// Index of the node
+	I int
+	// Encrypted bits of the share, from the least significant one
+	Bits []*EncBit
+	// Proof of the sum of the bits
+	Sum *dleq.Proof
+}
+
+// EncBit is the ElGamal encryption (R, C) of a bit b with a proof that b is
+// 0 or 1: the OR of the proofs that log_G(R) = log_X(C - j*G) for j = 0 and
+// j = 1, with the challenges C0 and C1 and the responses Z0 and Z1.
+type EncBit struct {
+	R, C   kyber.Point
+	C0, C1 kyber.Scalar
+	Z0, Z1 kyber.Scalar
+}
+
+// shareBits returns the number of bits of the shares: the bit length of the
+// order of the group if it implements kyber.GroupParameters, and the bit
+// length of the encoding of its scalars otherwise.
+func shareBits(g kyber.Group) int {
+	if params, ok := g.(kyber.GroupParameters); ok {
+		return params.Order().BitLen()
+	}
+	return 8 * g.ScalarLen()
+}
+
+// scalarBits returns the n least significant bits of s.
+func scalarBits(g kyber.Group, s kyber.Scalar, n int) ([]int64, error) {
+	buf, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	// MarshalBinary is big endian in some groups, as the ones of nist
+	one, err := g.Scalar().One().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if len(one) > 1 && one[len(one)-1] == 1 {
+		for i, j := 0, len(buf)-1; i < j; i, j = i+1, j-1 {
+			buf[i], buf[j] = buf[j], buf[i]
+		}
+	}
+	bits := make([]int64, n)
+	for k := range bits {
+		if k/8 < len(buf) {
+			bits[k] = int64(buf[k/8]>>uint(k%8)) & 1
+		}
+	}
+	return bits, nil
+}
+
+// encryptShare encrypts the share f of node i, whose public key is X, for
+// the dealer at the given index. F is f*G. It runs the same operations
+// whatever the bits of f.
+func encryptShare(suite Suite, dealer uint32, i int, X kyber.Point, f kyber.Scalar, F kyber.Point) (*EncShare, error) {
+	n := shareBits(suite)
+	bits, err := scalarBits(suite, f, n)
+	if err != nil {
+		return nil, err
+	}
+	G := suite.Point().Base()
+	stream := suite.RandomStream()
+	es := &EncShare{I: i, Bits: make([]*EncBit, n)}
+	r := suite.Scalar().Zero()
+	pow := suite.Scalar().One()
+	two := suite.Scalar().SetInt64(2)
+	for k, b := range bits {
+		rk := suite.Scalar().Pick(stream)
+		R := suite.Point().Mul(rk, nil)
+		C := suite.Point().Mul(suite.Scalar().SetInt64(b), nil)
+		C.Add(C, suite.Point().Mul(rk, X))
+
+		// the branch 1-b is simulated with a chosen challenge and response
+		w := suite.Scalar().Pick(stream)
+		cs := suite.Scalar().Pick(stream)
+		zs := suite.Scalar().Pick(stream)
+		Ys := suite.Point().Sub(C, suite.Point().Mul(suite.Scalar().SetInt64(1-b), nil))
+		var A [2][2]kyber.Point
+		A[b] = [2]kyber.Point{suite.Point().Mul(w, nil), suite.Point().Mul(w, X)}
+		A[1-b] = [2]kyber.Point{
+			suite.Point().Sub(suite.Point().Mul(zs, nil), suite.Point().Mul(cs, R)),
+			suite.Point().Sub(suite.Point().Mul(zs, X), suite.Point().Mul(cs, Ys)),
+		}
+		c, err := bitChallenge(suite, dealer, i, k, X, R, C, A)
+		if err != nil {
+			return nil, err
+		}
+		var ch, z [2]kyber.Scalar
+		ch[b] = suite.Scalar().Sub(c, cs)
+		z[b] = suite.Scalar().Add(w, suite.Scalar().Mul(ch[b], rk))
+		ch[1-b], z[1-b] = cs, zs
+		es.Bits[k] = &EncBit{R: R, C: C, C0: ch[0], C1: ch[1], Z0: z[0], Z1: z[1]}
+
+		r.Add(r, suite.Scalar().Mul(pow, rk))
+		pow.Mul(pow, two)
+	}
+	es.Sum, _, _, err = dleq.NewDLEQProof(suite, G, X, r)
+	if err != nil {
+		return nil, err
+	}
+	return es, nil
+}
+
+// verifyEncShare checks that es encrypts to X, bit by bit, the discrete
+// logarithm of F, for the dealer at the given index.
+func verifyEncShare(suite Suite, dealer uint32, X, F kyber.Point, es *EncShare) error {
+	if len(es.Bits) != shareBits(suite) || es.Sum == nil {
+		return errMalformed
+	}
+	G := suite.Point().Base()
+	sumR, sumC := suite.Point().Null(), suite.Point().Null()
+	pow := suite.Scalar().One()
+	two := suite.Scalar().SetInt64(2)
+	for k, eb := range es.Bits {
+		if eb == nil {
+			return errMalformed
+		}
+		var A [2][2]kyber.Point
+		for j, cz := range [][2]kyber.Scalar{{eb.C0, eb.Z0}, {eb.C1, eb.Z1}} {
+			Y := suite.Point().Sub(eb.C, suite.Point().Mul(suite.Scalar().SetInt64(int64(j)), nil))
+			A[j] = [2]kyber.Point{
+				suite.Point().Sub(suite.Point().Mul(cz[1], nil), suite.Point().Mul(cz[0], eb.R)),
+				suite.Point().Sub(suite.Point().Mul(cz[1], X), suite.Point().Mul(cz[0], Y)),
+			}
+		}
+		c, err := bitChallenge(suite, dealer, es.I, k, X, eb.R, eb.C, A)
+		if err != nil {
+			return err
+		}
+		if !c.Equal(suite.Scalar().Add(eb.C0, eb.C1)) {
+			return errors.New("pvdkg: invalid proof of an encrypted bit")
+		}
+		sumR.Add(sumR, suite.Point().Mul(pow, eb.R))
+		sumC.Add(sumC, suite.Point().Mul(pow, eb.C))
+		pow.Mul(pow, two)
+	}
+	if err := es.Sum.Verify(suite, G, X, sumR, sumC.Sub(sumC, F)); err != nil {
+		return errors.New("pvdkg: encrypted bits do not sum to the share")
+	}
+	return nil
+}
+
+// decryptShare decrypts es with the private key x of its node. The bits are
+// read without branching on their value.
+func decryptShare(suite Suite, x kyber.Scalar, es *EncShare) (kyber.Scalar, error) {
+	G, err := suite.Point().Base().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	f := suite.Scalar().Zero()
+	pow := suite.Scalar().One()
+	two := suite.Scalar().SetInt64(2)
+	for _, eb := range es.Bits {
+		// C - x*R is b*G
+		D := suite.Point().Sub(eb.C, suite.Point().Mul(x, eb.R))
+		buf, err := D.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		b := suite.Scalar().SetInt64(int64(subtle.ConstantTimeCompare(buf, G)))
+		f.Add(f, b.Mul(b, pow))
+		pow.Mul(pow, two)
+	}
+	return f, nil
+}
+
+// bitChallenge returns the challenge of the proof of the k-th encrypted bit
+// of the share of node i by the dealer, computed over the statement and the
+// commitments A of both branches.
+func bitChallenge(suite Suite, dealer uint32, i, k int, X, R, C kyber.Point, A [2][2]kyber.Point) (kyber.Scalar, error) {
+	h := suite.Hash()
+	_, _ = h.Write([]byte("pvdkg bit"))
+	_ = binary.Write(h, binary.LittleEndian, dealer)
+	_ = binary.Write(h, binary.LittleEndian, uint32(i))
+	_ = binary.Write(h, binary.LittleEndian, uint32(k))
+	for _, p := range []kyber.Point{X, R, C, A[0][0], A[0][1], A[1][0], A[1][1]} {
+		if p == nil {
+			return nil, errMalformed
+		}
+		if _, err := p.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	return suite.Scalar().Pick(suite.XOF(h.Sum(nil))), nil
+}
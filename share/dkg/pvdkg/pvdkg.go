@@ -0,0 +1,286 @@
+// Package pvdkg implements a publicly verifiable and non-interactive
+// distributed key generation: every node gets a scalar share of a secret
+// key, while anyone can check from the public board alone that the dealers
+// followed the protocol.
+//
+// Every dealer posts a single Dealing on the board: the Feldman commitments
+// to a random polynomial f, and for every node i the share f(i) encrypted to
+// the node's public key X_i, with a NIZK proof that the ciphertext holds the
+// discrete logarithm of F(i), the evaluation at i of the commitments. Anyone
+// can verify a Dealing with VerifyDealing, without any secret and without
+// interacting with the dealer, so there are no complaints nor
+// justifications. Aggregate combines the valid dealings into the same Result
+// for every observer, and each node obtains its share of the distributed key
+// with Result.DistKeyShare. The resulting key can be used as the keys of
+// share/dkg/pedersen, e.g. for threshold signatures with sign/dss.
+//
+// The shares are not encrypted with ECIES: a symmetric ciphertext can not be
+// proven consistent with the commitments without a generic proof system.
+// Instead every bit of the share is encrypted with ElGamal, with a proof
+// that it is a bit, and a DLEQ proof binds the weighted sum of the bits to
+// F(i). A dealing is thus a few hundred points per node, and its
+// verification is linear in the bit length of the group order.
+//
+// The longterm key of a node is used both as the Schnorr key signing its
+// dealing and as the ElGamal key decrypting its shares. The signature covers
+// a hash labelled as a dealing while the proofs of the shares use challenges
+// with their own labels, so one cannot be replayed as the other.
+package pvdkg
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+	dkg "go.dedis.ch/kyber/v3/share/dkg/pedersen"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+// Suite describes the functionalities needed by this package.
+type Suite interface {
+	kyber.Group
+	kyber.HashFactory
+	kyber.XOFFactory
+	kyber.Random
+}
+
+// Dealing is the message a dealer posts on the board.
+type Dealing struct {
+	// Index of the dealer in the list of nodes
+	Index uint32
+	// Feldman commitments to the polynomial of the dealer
+	Commits []kyber.Point
+	// Encrypted share of each node, with its proof of correct encryption
+	Shares []*EncShare
+	// Schnorr signature of the dealer over the hash of the dealing
+	Signature []byte
+}
+
+// NewDealing returns the signed dealing of the node with the longterm key,
+// sharing a fresh random secret with threshold t among nodes.
+func NewDealing(suite Suite, longterm kyber.Scalar, nodes []kyber.Point, t int) (*Dealing, error) {
+	index, err := findIndex(suite, longterm, nodes)
+	if err != nil {
+		return nil, err
+	}
+	if t < 1 || t > len(nodes) {
+		return nil, errors.New("pvdkg: invalid threshold")
+	}
+
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	priPoly := share.NewPriPoly(suite, t, secret, suite.RandomStream())
+	_, commits := priPoly.Commit(nil).Info()
+	d := &Dealing{
+		Index:   uint32(index),
+		Commits: commits,
+		Shares:  make([]*EncShare, len(nodes)),
+	}
+	for i, X := range nodes {
+		f := priPoly.Eval(i).V
+		F := suite.Point().Mul(f, nil)
+		if d.Shares[i], err = encryptShare(suite, d.Index, i, X, f, F); err != nil {
+			return nil, err
+		}
+	}
+	h, err := d.Hash(suite)
+	if err != nil {
+		return nil, err
+	}
+	d.Signature, err = schnorr.Sign(suite, longterm, h)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// errMalformed is returned for a dealing with a missing share, commitment or
+// proof element.
+var errMalformed = errors.New("pvdkg: malformed dealing")
+
+// Hash returns the hash of the dealing without its signature. It returns an
+// error if a share, a commitment or an element of a proof is missing.
+func (d *Dealing) Hash(suite Suite) ([]byte, error) {
+	h := suite.Hash()
+	_, _ = h.Write([]byte("pvdkg dealing"))
+	_ = binary.Write(h, binary.LittleEndian, d.Index)
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(d.Commits)))
+	for _, c := range d.Commits {
+		if c == nil {
+			return nil, errMalformed
+		}
+		if _, err := c.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(d.Shares)))
+	for _, s := range d.Shares {
+		if s == nil || s.Sum == nil || s.Sum.C == nil || s.Sum.R == nil || s.Sum.VG == nil || s.Sum.VH == nil {
+			return nil, errMalformed
+		}
+		_ = binary.Write(h, binary.LittleEndian, uint32(s.I))
+		_ = binary.Write(h, binary.LittleEndian, uint32(len(s.Bits)))
+		for _, b := range s.Bits {
+			if b == nil || b.R == nil || b.C == nil || b.C0 == nil || b.C1 == nil || b.Z0 == nil || b.Z1 == nil {
+				return nil, errMalformed
+			}
+			for _, m := range []kyber.Marshaling{b.R, b.C, b.C0, b.C1, b.Z0, b.Z1} {
+				if _, err := m.MarshalTo(h); err != nil {
+					return nil, err
+				}
+			}
+		}
+		for _, m := range []kyber.Marshaling{s.Sum.C, s.Sum.R, s.Sum.VG, s.Sum.VH} {
+			if _, err := m.MarshalTo(h); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// VerifyDealing checks publicly that the dealing is signed by its dealer,
+// that it commits to a polynomial of t coefficients, and that every node
+// received an encrypted share consistent with the commitments.
+func VerifyDealing(suite Suite, nodes []kyber.Point, t int, d *Dealing) error {
+	if d == nil {
+		return errors.New("pvdkg: nil dealing")
+	}
+	if d.Index >= uint32(len(nodes)) {
+		return errors.New("pvdkg: dealer index out of bounds")
+	}
+	h, err := d.Hash(suite)
+	if err != nil {
+		return err
+	}
+	if err := schnorr.Verify(suite, nodes[d.Index], h, d.Signature); err != nil {
+		return err
+	}
+	if len(d.Commits) != t {
+		return errors.New("pvdkg: number of commitments does not match the threshold")
+	}
+	if len(d.Shares) != len(nodes) {
+		return errors.New("pvdkg: number of shares does not match the number of nodes")
+	}
+	pubPoly := share.NewPubPoly(suite, nil, d.Commits)
+	for i, s := range d.Shares {
+		if s.I != i {
+			return fmt.Errorf("pvdkg: invalid index for the share of node %d", i)
+		}
+		if err := verifyEncShare(suite, d.Index, nodes[i], pubPoly.Eval(i).V, s); err != nil {
+			return fmt.Errorf("pvdkg: share of node %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of the dealings, which every observer of the board
+// computes identically from the same dealings.
+type Result struct {
+	// QUAL is the list of the dealers whose dealing is valid, in increasing
+	// order.
+	QUAL []int
+	// Public is the sum of the polynomials of the qualified dealers. Its
+	// commit is the distributed public key.
+	Public *share.PubPoly
+
+	dealings []*Dealing
+	nodes    []kyber.Point
+}
+
+// Aggregate verifies the dealings and combines the valid ones into a
+// Result. Invalid and nil dealings are ignored, and so are copies of a valid
+// dealing, even with another signature. A dealer that signed two different
+// valid dealings is excluded from QUAL, whatever the order of the dealings,
+// so that the Result does not depend on which one an observer saw first.
+// An error is returned if fewer than t dealers have a valid dealing.
+func Aggregate(suite Suite, nodes []kyber.Point, t int, dealings []*Dealing) (*Result, error) {
+	valid := make(map[uint32]*Dealing)
+	hashes := make(map[uint32][]byte)
+	equivocating := make(map[uint32]bool)
+	for _, d := range dealings {
+		if d == nil || equivocating[d.Index] {
+			continue
+		}
+		h, err := d.Hash(suite)
+		if err != nil {
+			continue
+		}
+		if prev, ok := hashes[d.Index]; ok && bytes.Equal(prev, h) {
+			continue
+		}
+		if VerifyDealing(suite, nodes, t, d) != nil {
+			continue
+		}
+		if _, ok := valid[d.Index]; ok {
+			equivocating[d.Index] = true
+			delete(valid, d.Index)
+			continue
+		}
+		valid[d.Index] = d
+		hashes[d.Index] = h
+	}
+	if len(valid) < t {
+		return nil, fmt.Errorf("pvdkg: %d valid dealings, need %d", len(valid), t)
+	}
+
+	r := &Result{nodes: nodes}
+	for i := range nodes {
+		d, ok := valid[uint32(i)]
+		if !ok {
+			continue
+		}
+		r.QUAL = append(r.QUAL, i)
+		r.dealings = append(r.dealings, d)
+		pubPoly := share.NewPubPoly(suite, nil, d.Commits)
+		if r.Public == nil {
+			r.Public = pubPoly
+		} else {
+			var err error
+			if r.Public, err = r.Public.Add(pubPoly); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return r, nil
+}
+
+// DistKeyShare decrypts the shares of the node with the longterm key from
+// every qualified dealing and returns its share of the distributed key.
+func (r *Result) DistKeyShare(suite Suite, longterm kyber.Scalar) (*dkg.DistKeyShare, error) {
+	i, err := findIndex(suite, longterm, r.nodes)
+	if err != nil {
+		return nil, err
+	}
+	s := suite.Scalar().Zero()
+	for _, d := range r.dealings {
+		f, err := decryptShare(suite, longterm, d.Shares[i])
+		if err != nil {
+			return nil, err
+		}
+		// the proofs guarantee it, but a wrong share must never be used
+		F := share.NewPubPoly(suite, nil, d.Commits).Eval(i).V
+		if !suite.Point().Mul(f, nil).Equal(F) {
+			return nil, fmt.Errorf("pvdkg: share of dealer %d does not match its commitments", d.Index)
+		}
+		s.Add(s, f)
+	}
+	_, commits := r.Public.Info()
+	return &dkg.DistKeyShare{
+		Commits: commits,
+		Share:   &share.PriShare{I: i, V: s},
+	}, nil
+}
+
+// findIndex returns the index in nodes of the public key of longterm.
+func findIndex(suite Suite, longterm kyber.Scalar, nodes []kyber.Point) (int, error) {
+	pub := suite.Point().Mul(longterm, nil)
+	for i, n := range nodes {
+		if n.Equal(pub) {
+			return i, nil
+		}
+	}
+	return -1, errors.New("pvdkg: public key not found in the list of nodes")
+}
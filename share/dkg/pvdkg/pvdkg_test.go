@@ -0,0 +1,117 @@
+package pvdkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func TestPVDKG(t *testing.T) {
+	n, th := 4, 3
+	secrets := make([]kyber.Scalar, n)
+	nodes := make([]kyber.Point, n)
+	for i := range nodes {
+		secrets[i] = suite.Scalar().Pick(suite.RandomStream())
+		nodes[i] = suite.Point().Mul(secrets[i], nil)
+	}
+
+	// every node posts its dealing on the board
+	var board []*Dealing
+	for i := range nodes {
+		d, err := NewDealing(suite, secrets[i], nodes, th)
+		require.NoError(t, err)
+		board = append(board, d)
+	}
+
+	// a public verifier checks every dealing without any secret
+	for _, d := range board {
+		require.NoError(t, VerifyDealing(suite, nodes, th, d))
+	}
+
+	// a dealing encrypting a share inconsistent with the commitments, with
+	// valid proofs for every bit, signed again by its dealer
+	bad := *board[3]
+	bad.Shares = append([]*EncShare{}, board[3].Shares...)
+	F := share.NewPubPoly(suite, nil, bad.Commits).Eval(1).V
+	wrong, err := encryptShare(suite, bad.Index, 1, nodes[1], suite.Scalar().Pick(suite.RandomStream()), F)
+	require.NoError(t, err)
+	bad.Shares[1] = wrong
+	h, err := bad.Hash(suite)
+	require.NoError(t, err)
+	bad.Signature, err = schnorr.Sign(suite, secrets[3], h)
+	require.NoError(t, err)
+	require.Error(t, VerifyDealing(suite, nodes, th, &bad))
+	// a share whose bits are moved to another node
+	moved := *board[2]
+	moved.Shares = append([]*EncShare{}, board[2].Shares...)
+	moved.Shares[0] = &EncShare{I: 0, Bits: board[2].Shares[1].Bits, Sum: board[2].Shares[1].Sum}
+	require.Error(t, VerifyDealing(suite, nodes, th, &moved))
+	// a dealing with a missing bit
+	missing := *board[2]
+	missing.Shares = append([]*EncShare{}, board[2].Shares...)
+	missing.Shares[0] = &EncShare{I: 0, Bits: board[2].Shares[0].Bits[1:], Sum: board[2].Shares[0].Sum}
+	require.Error(t, VerifyDealing(suite, nodes, th, &missing))
+	// a dealing claimed by another dealer
+	stolen := *board[2]
+	stolen.Index = 1
+	require.Error(t, VerifyDealing(suite, nodes, th, &stolen))
+
+	// every observer computes the same result, without the bad dealing
+	res, err := Aggregate(suite, nodes, th, append(board[:3:3], &bad))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, res.QUAL)
+	again, err := Aggregate(suite, nodes, th, []*Dealing{&bad, board[2], nil, board[0], board[1], board[1]})
+	require.NoError(t, err)
+	require.Equal(t, res.QUAL, again.QUAL)
+	require.True(t, res.Public.Equal(again.Public))
+	_, err = Aggregate(suite, nodes, th, board[:2])
+	require.Error(t, err)
+
+	// every node decrypts a scalar share of the distributed key
+	shares := make([]*share.PriShare, n)
+	for i := range nodes {
+		dks, err := res.DistKeyShare(suite, secrets[i])
+		require.NoError(t, err)
+		require.Equal(t, i, dks.Share.I)
+		require.True(t, dks.Public().Equal(res.Public.Commit()))
+		require.True(t, suite.Point().Mul(dks.Share.V, nil).Equal(res.Public.Eval(i).V))
+		shares[i] = dks.Share
+	}
+	_, err = res.DistKeyShare(suite, suite.Scalar().Pick(suite.RandomStream()))
+	require.Error(t, err)
+
+	// any t shares give the private key of the distributed public key
+	x1, err := share.RecoverSecret(suite, shares[:th], th, n)
+	require.NoError(t, err)
+	x2, err := share.RecoverSecret(suite, shares[1:], th, n)
+	require.NoError(t, err)
+	require.True(t, x1.Equal(x2))
+	require.True(t, suite.Point().Mul(x1, nil).Equal(res.Public.Commit()))
+}
+
+func TestEncShareBits(t *testing.T) {
+	x := suite.Scalar().Pick(suite.RandomStream())
+	X := suite.Point().Mul(x, nil)
+	for _, f := range []kyber.Scalar{
+		suite.Scalar().Zero(),
+		suite.Scalar().One(),
+		suite.Scalar().SetInt64(-1),
+		suite.Scalar().Pick(suite.RandomStream()),
+	} {
+		F := suite.Point().Mul(f, nil)
+		es, err := encryptShare(suite, 0, 2, X, f, F)
+		require.NoError(t, err)
+		require.NoError(t, verifyEncShare(suite, 0, X, F, es))
+		// the proofs are bound to the dealer
+		require.Error(t, verifyEncShare(suite, 1, X, F, es))
+		dec, err := decryptShare(suite, x, es)
+		require.NoError(t, err)
+		require.True(t, dec.Equal(f))
+	}
+}
@@ -0,0 +1,383 @@
+package share
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Interpolator recovers the secret p(0) from at least t private shares of a
+// polynomial p. Implementations differ only in their cost.
+type Interpolator interface {
+	RecoverSecret(g kyber.Group, shares []*PriShare, t, n int) (kyber.Scalar, error)
+}
+
+// LagrangeInterpolator is the default Interpolator, used by the package-level
+// RecoverSecret. It computes each Lagrange coefficient separately, with one
+// scalar inversion per share and O(t^2) scalar multiplications.
+type LagrangeInterpolator struct{}
+
+// RecoverSecret implements the Interpolator interface.
+func (LagrangeInterpolator) RecoverSecret(g kyber.Group, shares []*PriShare, t, n int) (kyber.Scalar, error) {
+	return RecoverSecret(g, shares, t, n)
+}
+
+// BarycentricInterpolator is an Interpolator for large thresholds. It
+// computes the barycentric weights -x_i * M'(x_i) of the share indices x_i,
+// where M(X) = prod_j (X - x_j), and inverts them all at once with a single
+// inversion. It returns the same secret as LagrangeInterpolator.
+//
+// Below treeThreshold shares, the weights are products of small integers,
+// computed mostly with machine arithmetic, which divides the O(t^2) scalar
+// multiplications by the number of index differences that fit in 63 bits.
+// From treeThreshold shares, M is built with a product tree and M' is
+// evaluated at every x_i with a remainder tree, with O(t^1.59 log t) scalar
+// multiplications using Karatsuba multiplication and Newton division. The
+// O(t log^2 t) bound of the FFT variant does not apply, since the scalar
+// fields of the groups lack the roots of unity it needs, and the trees only
+// win for very large t, see BenchmarkTreeWeights512.
+type BarycentricInterpolator struct{}
+
+// treeThreshold is the number of shares from which BarycentricInterpolator
+// uses the product and remainder trees. With edwards25519, the integer
+// products are 7 times faster at t = 512 and still 3 times faster at
+// t = 4096.
+const treeThreshold = 1 << 16
+
+// RecoverSecret implements the Interpolator interface.
+func (BarycentricInterpolator) RecoverSecret(g kyber.Group, shares []*PriShare, t, n int) (kyber.Scalar, error) {
+	sel := selectShares(shares, t)
+	if len(sel) < t {
+		return nil, errors.New("share: not enough shares to recover secret")
+	}
+
+	// p(0) = sum_i y_i * l_i(0), with l_i(0) = N / (-x_i * prod_{j!=i} (x_i - x_j))
+	// and N = prod_j (-x_j).
+	var dens []kyber.Scalar
+	if len(sel) < treeThreshold {
+		dens = intWeights(g, sel)
+	} else {
+		dens = treeWeights(g, sel)
+	}
+	batchInvert(g, dens)
+
+	num := newIntProduct(g)
+	for _, s := range sel {
+		num.mul(-int64(s.I + 1))
+	}
+	acc := g.Scalar().Zero()
+	tmp := g.Scalar()
+	for i, s := range sel {
+		acc.Add(acc, tmp.Mul(s.V, dens[i]))
+	}
+	return acc.Mul(acc, num.scalar()), nil
+}
+
+// intWeights returns the weights -x_i * prod_{j!=i} (x_i - x_j) of the
+// shares, as products of integers.
+func intWeights(g kyber.Group, sel []*PriShare) []kyber.Scalar {
+	dens := make([]kyber.Scalar, len(sel))
+	for i, si := range sel {
+		xi := int64(si.I + 1)
+		p := newIntProduct(g)
+		p.mul(-xi)
+		for j, sj := range sel {
+			if i != j {
+				p.mul(xi - int64(sj.I+1))
+			}
+		}
+		dens[i] = p.scalar()
+	}
+	return dens
+}
+
+// treeWeights returns the weights -x_i * M'(x_i) of the shares, evaluating
+// M' with a remainder tree over the product tree of M.
+func treeWeights(g kyber.Group, sel []*PriShare) []kyber.Scalar {
+	xs := make([]kyber.Scalar, len(sel))
+	for i, s := range sel {
+		xs[i] = g.Scalar().SetInt64(int64(s.I + 1))
+	}
+	tree := productTree(g, xs)
+	root := tree[len(tree)-1][0]
+
+	// M' has a degree lower than M, so it is its own remainder at the root
+	deriv := make([]kyber.Scalar, len(root)-1)
+	for k := range deriv {
+		deriv[k] = g.Scalar().Mul(root[k+1], g.Scalar().SetInt64(int64(k+1)))
+	}
+	rems := [][]kyber.Scalar{deriv}
+	for l := len(tree) - 2; l >= 0; l-- {
+		next := make([][]kyber.Scalar, len(tree[l]))
+		for k, m := range tree[l] {
+			next[k] = polyMod(g, rems[k/2], m)
+		}
+		rems = next
+	}
+
+	dens := make([]kyber.Scalar, len(sel))
+	for i := range dens {
+		dens[i] = g.Scalar().Neg(xs[i])
+		dens[i].Mul(dens[i], rems[i][0])
+	}
+	return dens
+}
+
+// productTree returns the levels of the product tree of the monic
+// polynomials X - x_i, from the leaves to the root. Node k of a level is the
+// product of the nodes 2k and 2k+1 of the level below, or the node 2k alone
+// if it is the last one. Polynomials are lists of coefficients, from the
+// constant one.
+func productTree(g kyber.Group, xs []kyber.Scalar) [][][]kyber.Scalar {
+	level := make([][]kyber.Scalar, len(xs))
+	for i, x := range xs {
+		level[i] = []kyber.Scalar{g.Scalar().Neg(x), g.Scalar().One()}
+	}
+	tree := [][][]kyber.Scalar{level}
+	for len(level) > 1 {
+		next := make([][]kyber.Scalar, (len(level)+1)/2)
+		for k := range next {
+			if 2*k+1 < len(level) {
+				next[k] = polyMul(g, level[2*k], level[2*k+1])
+			} else {
+				next[k] = level[2*k]
+			}
+		}
+		tree = append(tree, next)
+		level = next
+	}
+	return tree
+}
+
+// karatsubaThreshold is the length below which polyMul multiplies with the
+// schoolbook method.
+const karatsubaThreshold = 16
+
+// polyMul returns the product of a and b, with the Karatsuba method.
+func polyMul(g kyber.Group, a, b []kyber.Scalar) []kyber.Scalar {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	if len(b) < karatsubaThreshold {
+		res := polyZero(g, len(a)+len(b)-1)
+		tmp := g.Scalar()
+		for i, ai := range a {
+			for j, bj := range b {
+				res[i+j].Add(res[i+j], tmp.Mul(ai, bj))
+			}
+		}
+		return res
+	}
+
+	// a = a0 + X^h a1 and b = b0 + X^h b1
+	h := (len(a) + 1) / 2
+	a0, a1 := a[:h], a[h:]
+	b0, b1 := b, []kyber.Scalar(nil)
+	if len(b) > h {
+		b0, b1 = b[:h], b[h:]
+	}
+	z0 := polyMul(g, a0, b0)
+	z2 := polyMul(g, a1, b1)
+	z1 := polyMul(g, polyAdd(g, a0, a1), polyAdd(g, b0, b1))
+	res := polyZero(g, len(a)+len(b)-1)
+	for i, c := range z0 {
+		res[i].Add(res[i], c)
+		z1[i].Sub(z1[i], c)
+	}
+	for i, c := range z2 {
+		res[i+2*h].Add(res[i+2*h], c)
+		z1[i].Sub(z1[i], c)
+	}
+	for i, c := range z1 {
+		if i+h < len(res) {
+			res[i+h].Add(res[i+h], c)
+		}
+	}
+	return res
+}
+
+// newtonThreshold is the degree from which polyMod divides with a Newton
+// inversion rather than with the schoolbook method.
+const newtonThreshold = 512
+
+// polyMod returns the remainder of the division of a by the monic
+// polynomial m of degree d, as d coefficients. For large degrees, the
+// quotient is computed with a Newton inversion of the reverse of m.
+func polyMod(g kyber.Group, a, m []kyber.Scalar) []kyber.Scalar {
+	d := len(m) - 1
+	if len(a) <= d {
+		return a
+	}
+	if d < newtonThreshold {
+		r := make([]kyber.Scalar, len(a))
+		for i, c := range a {
+			r[i] = c.Clone()
+		}
+		tmp := g.Scalar()
+		for i := len(a) - 1; i >= d; i-- {
+			// subtract r_i * X^(i-d) * m
+			q := r[i]
+			for j := 0; j < d; j++ {
+				r[i-d+j].Sub(r[i-d+j], tmp.Mul(q, m[j]))
+			}
+		}
+		return r[:d]
+	}
+	k := len(a) - d
+	inv := polyInvRev(g, m, k)
+	q := polyMul(g, polyRev(a)[:k], inv)[:k]
+	q = polyRev(q)
+	qm := polyMul(g, q, m)
+	r := make([]kyber.Scalar, d)
+	for i := range r {
+		r[i] = g.Scalar().Sub(a[i], qm[i])
+	}
+	return r
+}
+
+// polyInvRev returns the inverse modulo X^k of the reverse of the monic
+// polynomial m, with Newton iterations.
+func polyInvRev(g kyber.Group, m []kyber.Scalar, k int) []kyber.Scalar {
+	f := polyRev(m)
+	inv := []kyber.Scalar{g.Scalar().One()}
+	two := g.Scalar().SetInt64(2)
+	for l := 1; l < k; {
+		l *= 2
+		if l > k {
+			l = k
+		}
+		// inv = inv * (2 - f * inv) mod X^l
+		e := polyMul(g, f[:minInt(l, len(f))], inv)
+		e = e[:minInt(l, len(e))]
+		for i := range e {
+			e[i].Neg(e[i])
+		}
+		e[0].Add(e[0], two)
+		inv = polyMul(g, inv, e)
+		inv = inv[:minInt(l, len(inv))]
+	}
+	return inv
+}
+
+func polyAdd(g kyber.Group, a, b []kyber.Scalar) []kyber.Scalar {
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	res := make([]kyber.Scalar, len(a))
+	for i := range a {
+		res[i] = a[i].Clone()
+		if i < len(b) {
+			res[i].Add(res[i], b[i])
+		}
+	}
+	return res
+}
+
+func polyRev(a []kyber.Scalar) []kyber.Scalar {
+	res := make([]kyber.Scalar, len(a))
+	for i, c := range a {
+		res[len(a)-1-i] = c
+	}
+	return res
+}
+
+func polyZero(g kyber.Group, n int) []kyber.Scalar {
+	res := make([]kyber.Scalar, n)
+	for i := range res {
+		res[i] = g.Scalar().Zero()
+	}
+	return res
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// selectShares returns the first t valid shares of distinct indices, sorted
+// by index, the same shares as xyScalar uses.
+func selectShares(shares []*PriShare, t int) []*PriShare {
+	sorted := make([]*PriShare, 0, len(shares))
+	for _, s := range shares {
+		if s != nil && s.V != nil && s.I >= 0 {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.Sort(byIndexScalar(sorted))
+	sel := make([]*PriShare, 0, t)
+	for _, s := range sorted {
+		if len(sel) == t {
+			break
+		}
+		if len(sel) > 0 && sel[len(sel)-1].I == s.I {
+			continue
+		}
+		sel = append(sel, s)
+	}
+	return sel
+}
+
+// intProduct multiplies non-zero integers together, accumulating them in a
+// uint64 as long as possible before multiplying them into a scalar.
+type intProduct struct {
+	s   kyber.Scalar
+	tmp kyber.Scalar
+	acc uint64
+	neg bool
+}
+
+func newIntProduct(g kyber.Group) *intProduct {
+	return &intProduct{s: g.Scalar().One(), tmp: g.Scalar(), acc: 1}
+}
+
+func (p *intProduct) mul(v int64) {
+	if v < 0 {
+		p.neg = !p.neg
+		v = -v
+	}
+	if p.acc > math.MaxInt64/uint64(v) {
+		p.flush()
+	}
+	p.acc *= uint64(v)
+}
+
+func (p *intProduct) flush() {
+	p.s.Mul(p.s, p.tmp.SetInt64(int64(p.acc)))
+	p.acc = 1
+}
+
+func (p *intProduct) scalar() kyber.Scalar {
+	p.flush()
+	if p.neg {
+		p.s.Neg(p.s)
+	}
+	return p.s
+}
+
+// batchInvert replaces every scalar of s by its inverse, with a single
+// inversion. None of them may be zero.
+func batchInvert(g kyber.Group, s []kyber.Scalar) {
+	if len(s) == 0 {
+		return
+	}
+	prefix := make([]kyber.Scalar, len(s))
+	prefix[0] = s[0].Clone()
+	for i := 1; i < len(s); i++ {
+		prefix[i] = g.Scalar().Mul(prefix[i-1], s[i])
+	}
+	inv := g.Scalar().Inv(prefix[len(s)-1])
+	tmp := g.Scalar()
+	for i := len(s) - 1; i > 0; i-- {
+		tmp.Mul(inv, prefix[i-1])
+		inv.Mul(inv, s[i])
+		s[i].Set(tmp)
+	}
+	s[0].Set(inv)
+}
@@ -0,0 +1,113 @@
+package share
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+func TestBarycentricInterpolator(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	for _, tn := range []struct{ t, n int }{{1, 1}, {2, 3}, {7, 10}, {100, 300}, {300, 400}} {
+		poly := NewPriPoly(g, tn.t, nil, g.RandomStream())
+		shares := poly.Shares(tn.n)
+		rand.Shuffle(len(shares), func(i, j int) { shares[i], shares[j] = shares[j], shares[i] })
+		// drop some shares and duplicate another one
+		if tn.n > tn.t {
+			shares[0] = nil
+			shares = append(shares, shares[1])
+		}
+
+		var interp Interpolator = BarycentricInterpolator{}
+		secret, err := interp.RecoverSecret(g, shares, tn.t, tn.n)
+		require.NoError(test, err)
+		require.True(test, poly.Secret().Equal(secret))
+
+		naive, err := LagrangeInterpolator{}.RecoverSecret(g, shares, tn.t, tn.n)
+		require.NoError(test, err)
+		require.True(test, naive.Equal(secret))
+	}
+
+	poly := NewPriPoly(g, 5, nil, g.RandomStream())
+	_, err := BarycentricInterpolator{}.RecoverSecret(g, poly.Shares(4), 5, 4)
+	require.Error(test, err)
+}
+
+func TestBarycentricWeights(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	for _, t := range []int{1, 2, 3, 17, 64, 100, 333} {
+		idx := rand.Perm(3 * t)[:t]
+		shares := make([]*PriShare, t)
+		for i, j := range idx {
+			shares[i] = &PriShare{I: j, V: g.Scalar().One()}
+		}
+		sel := selectShares(shares, t)
+		want := intWeights(g, sel)
+		got := treeWeights(g, sel)
+		for i := range want {
+			require.True(test, want[i].Equal(got[i]))
+		}
+	}
+}
+
+func TestPolyMod(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	random := func(n int) []kyber.Scalar {
+		p := make([]kyber.Scalar, n)
+		for i := range p {
+			p[i] = g.Scalar().Pick(g.RandomStream())
+		}
+		return p
+	}
+	// both division methods give a = q*m + r
+	for _, d := range []int{1, 5, newtonThreshold - 1, newtonThreshold, newtonThreshold + 7} {
+		m := append(random(d), g.Scalar().One())
+		q := random(d - 1 + 1)
+		r := random(d)
+		a := polyAdd(g, polyMul(g, q, m), r)
+		got := polyMod(g, a, m)
+		require.Len(test, got, d)
+		for i := range r {
+			require.True(test, r[i].Equal(got[i]))
+		}
+	}
+}
+
+func benchmarkInterpolator(b *testing.B, interp Interpolator) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	t, n := 512, 1024
+	poly := NewPriPoly(g, t, nil, g.RandomStream())
+	shares := poly.Shares(n)[n-t:]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		interp.RecoverSecret(g, shares, t, n)
+	}
+}
+
+func BenchmarkLagrangeInterpolator512(b *testing.B) {
+	benchmarkInterpolator(b, LagrangeInterpolator{})
+}
+
+func BenchmarkBarycentricInterpolator512(b *testing.B) {
+	benchmarkInterpolator(b, BarycentricInterpolator{})
+}
+
+func benchmarkWeights(b *testing.B, weights func(kyber.Group, []*PriShare) []kyber.Scalar, t int) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	shares := NewPriPoly(g, t, nil, g.RandomStream()).Shares(2 * t)[t:]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		weights(g, shares)
+	}
+}
+
+func BenchmarkIntWeights512(b *testing.B) {
+	benchmarkWeights(b, intWeights, 512)
+}
+
+func BenchmarkTreeWeights512(b *testing.B) {
+	benchmarkWeights(b, treeWeights, 512)
+}
@@ -0,0 +1,75 @@
+package share
+
+import (
+	"encoding/binary"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// hashablePoint is implemented by the points of the groups that can hash
+// bytes to a point without implementing kyber.PointGenerator.
+type hashablePoint interface {
+	Hash([]byte) kyber.Point
+}
+
+// PedersenCommit returns the Pedersen commitment m*B + r*h to the message m
+// with the blinding factor r, where B is the standard base point of g and h
+// a second generator whose discrete logarithm with respect to B must be
+// unknown, such as one returned by PedersenGenerator. The commitment hides m
+// as long as r is random and binds the committer to m and r.
+func PedersenCommit(g kyber.Group, m, r kyber.Scalar, h kyber.Point) kyber.Point {
+	mB := g.Point().Mul(m, nil)
+	rh := g.Point().Mul(r, h)
+	return mB.Add(mB, rh)
+}
+
+// VerifyPedersenCommit returns true if c is the Pedersen commitment of m
+// with the blinding factor r under the generator h.
+func VerifyPedersenCommit(g kyber.Group, c kyber.Point, m, r kyber.Scalar, h kyber.Point) bool {
+	return PedersenCommit(g, m, r, h).Equal(c)
+}
+
+// PedersenGenerator derives a generator h suitable for PedersenCommit by
+// hashing domain to a point of g. Since h is the output of a hash function,
+// nobody knows its discrete logarithm with respect to the base point. A
+// distinct domain should be used by each protocol. The group must implement
+// kyber.PointGenerator, or its points must have a Hash method mapping bytes
+// to a point, as the ones of the groups of pairing/bn256. Deriving h with
+// Pick is not enough: Pick multiplies the base point by a random scalar in
+// some groups, whose discrete logarithm anybody could recompute.
+func PedersenGenerator(g kyber.Group, domain []byte) (kyber.Point, error) {
+	var h kyber.Point
+	if gen, ok := g.(kyber.PointGenerator); ok {
+		h = gen.HashToPoint(domain)
+	} else if p, ok := g.Point().(hashablePoint); ok {
+		h = p.Hash(domain)
+	} else {
+		return nil, errors.New("share: group can not hash to a point")
+	}
+	if h.Equal(g.Point().Null()) || h.Equal(g.Point().Base()) {
+		return nil, errors.New("share: derived generator is degenerate")
+	}
+	return h, nil
+}
+
+// PedersenGenerators derives n generators with PedersenGenerator, the i-th
+// one from domain followed by i as a 4-byte big-endian integer. They are
+// "nothing-up-my-sleeve" points: since each one is the output of a hash
+// function, no discrete logarithm relation between them or with the base
+// point is known, as needed by vector Pedersen commitments and range proofs.
+// The same domain and n always give the same generators.
+func PedersenGenerators(g kyber.Group, domain []byte, n int) ([]kyber.Point, error) {
+	gens := make([]kyber.Point, n)
+	buf := make([]byte, len(domain)+4)
+	copy(buf, domain)
+	for i := range gens {
+		binary.BigEndian.PutUint32(buf[len(domain):], uint32(i))
+		h, err := PedersenGenerator(g, buf)
+		if err != nil {
+			return nil, err
+		}
+		gens[i] = h
+	}
+	return gens, nil
+}
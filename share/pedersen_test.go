@@ -0,0 +1,132 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/group/nist"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/util/random"
+	"go.dedis.ch/kyber/v3/xof/blake2xb"
+)
+
+// pedersenGroups are the groups the tests derive generators in, including
+// the ones of a pairing, whose Pick gives points of known discrete
+// logarithm.
+func pedersenGroups() []kyber.Group {
+	pairing := bn256.NewSuite()
+	return []kyber.Group{
+		edwards25519.NewBlakeSHA256Ed25519(),
+		nist.NewBlakeSHA256P256(),
+		pairing.G1(),
+		pairing.G2(),
+		bn256.NewSuiteG1(),
+	}
+}
+
+func TestPedersenCommit(t *testing.T) {
+	stream := random.New()
+	for _, g := range pedersenGroups() {
+		h, err := PedersenGenerator(g, []byte("pedersen test"))
+		require.NoError(t, err, g.String())
+
+		m := g.Scalar().Pick(stream)
+		r := g.Scalar().Pick(stream)
+		c := PedersenCommit(g, m, r, h)
+		require.True(t, VerifyPedersenCommit(g, c, m, r, h))
+
+		// binding: the commitment does not open to another message or
+		// blinding
+		m2 := g.Scalar().Add(m, g.Scalar().One())
+		require.False(t, VerifyPedersenCommit(g, c, m2, r, h))
+		require.False(t, VerifyPedersenCommit(g, c, m, g.Scalar().Add(r, g.Scalar().One()), h))
+		// nor under another generator
+		require.False(t, VerifyPedersenCommit(g, c, m, r, g.Point().Base()))
+
+		// hiding: the same message committed twice gives different
+		// commitments
+		r2 := g.Scalar().Pick(stream)
+		require.False(t, c.Equal(PedersenCommit(g, m, r2, h)))
+	}
+}
+
+func TestPedersenGenerator(t *testing.T) {
+	for _, g := range pedersenGroups() {
+		h1, err := PedersenGenerator(g, []byte("domain 1"))
+		require.NoError(t, err, g.String())
+		h1bis, err := PedersenGenerator(g, []byte("domain 1"))
+		require.NoError(t, err)
+		h2, err := PedersenGenerator(g, []byte("domain 2"))
+		require.NoError(t, err)
+
+		require.True(t, h1.Equal(h1bis))
+		require.False(t, h1.Equal(h2))
+		require.False(t, h1.Equal(g.Point().Base()))
+
+		// h must not be a small multiple of the base point
+		multiple := g.Point().Null()
+		for i := 0; i < 1000; i++ {
+			multiple.Add(multiple, g.Point().Base())
+			require.False(t, h1.Equal(multiple))
+		}
+	}
+
+	// in bn256, a point picked from a stream seeded with the domain is the
+	// base point times a scalar that anybody can recompute
+	g := bn256.NewSuite().G1()
+	h, err := PedersenGenerator(g, []byte("domain"))
+	require.NoError(t, err)
+	known := g.Scalar().Pick(blake2xb.New([]byte("domain")))
+	require.True(t, g.Point().Pick(blake2xb.New([]byte("domain"))).Equal(g.Point().Mul(known, nil)))
+	require.False(t, h.Equal(g.Point().Mul(known, nil)))
+
+	// groups without hash to point support are rejected, even with a XOF
+	_, err = PedersenGenerator(plainGroup{edwards25519.NewBlakeSHA256Ed25519()}, []byte("domain"))
+	require.Error(t, err)
+	_, err = PedersenGenerator(xofGroup{edwards25519.NewBlakeSHA256Ed25519()}, []byte("domain"))
+	require.Error(t, err)
+}
+
+func TestPedersenGenerators(t *testing.T) {
+	n := 16
+	for _, g := range pedersenGroups() {
+		gens, err := PedersenGenerators(g, []byte("generators"), n)
+		require.NoError(t, err)
+		require.Len(t, gens, n)
+		again, err := PedersenGenerators(g, []byte("generators"), n)
+		require.NoError(t, err)
+		other, err := PedersenGenerators(g, []byte("other generators"), n)
+		require.NoError(t, err)
+
+		for i, h := range gens {
+			require.True(t, h.Equal(again[i]))
+			require.False(t, h.Equal(other[i]))
+			require.False(t, h.Equal(g.Point().Base()))
+			for _, h2 := range gens[:i] {
+				require.False(t, h.Equal(h2))
+			}
+		}
+	}
+
+	_, err := PedersenGenerators(plainGroup{edwards25519.NewBlakeSHA256Ed25519()}, []byte("generators"), n)
+	require.Error(t, err)
+}
+
+// plainGroup hides every method of the wrapped group that is not part of
+// kyber.Group.
+type plainGroup struct {
+	kyber.Group
+}
+
+// xofGroup hides every method of the wrapped suite that is not part of
+// kyber.Group or kyber.XOFFactory.
+type xofGroup struct {
+	xofSuite
+}
+
+type xofSuite interface {
+	kyber.Group
+	kyber.XOFFactory
+}
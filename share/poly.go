@@ -205,6 +205,42 @@ func RecoverSecret(g kyber.Group, shares []*PriShare, t, n int) (kyber.Scalar, e
 	return acc, nil
 }
 
+// LagrangeCoefficients returns the Lagrange coefficients l_i, indexed by i,
+// such that p(x) = sum of l_i * p(i+1) over the given share indices, for any
+// polynomial p of degree lower than len(indices). Share indices follow the
+// convention of PriShare, where index i holds p(i+1), so x = 0 gives the
+// coefficients that recover the secret. The indices must be distinct and
+// non-negative.
+func LagrangeCoefficients(g kyber.Group, indices []int, x int) (map[int]kyber.Scalar, error) {
+	xs := make(map[int]kyber.Scalar, len(indices))
+	for _, i := range indices {
+		if i < 0 {
+			return nil, fmt.Errorf("share: invalid index %d", i)
+		}
+		if _, ok := xs[i]; ok {
+			return nil, fmt.Errorf("share: duplicate index %d", i)
+		}
+		xs[i] = g.Scalar().SetInt64(int64(i + 1))
+	}
+
+	at := g.Scalar().SetInt64(int64(x))
+	tmp := g.Scalar()
+	coeffs := make(map[int]kyber.Scalar, len(xs))
+	for i, xi := range xs {
+		num := g.Scalar().One()
+		den := g.Scalar().One()
+		for j, xj := range xs {
+			if i == j {
+				continue
+			}
+			num.Mul(num, tmp.Sub(at, xj))
+			den.Mul(den, tmp.Sub(xi, xj))
+		}
+		coeffs[i] = num.Div(num, den)
+	}
+	return coeffs, nil
+}
+
 type byIndexScalar []*PriShare
 
 func (s byIndexScalar) Len() int           { return len(s) }
@@ -286,6 +322,20 @@ func RecoverPriPoly(g kyber.Group, shares []*PriShare, t, n int) (*PriPoly, erro
 	return accPoly, nil
 }
 
+// RecoverCoefficients returns all t coefficients of the secret polynomial
+// that produced the given shares, the first one being the shared secret.
+// It is meant for debugging and forensic analysis: whoever runs it learns
+// the whole secret polynomial, and with it every share that was or will be
+// derived from it, so it must only run in a trusted context. There must be
+// at least t shares.
+func RecoverCoefficients(g kyber.Group, shares []*PriShare, t, n int) ([]kyber.Scalar, error) {
+	poly, err := RecoverPriPoly(g, shares, t, n)
+	if err != nil {
+		return nil, err
+	}
+	return poly.Coefficients(), nil
+}
+
 func (p *PriPoly) String() string {
 	var strs = make([]string, len(p.coeffs))
 	for i, c := range p.coeffs {
@@ -469,6 +519,32 @@ func RecoverCommit(g kyber.Group, shares []*PubShare, t, n int) (kyber.Point, er
 	return Acc, nil
 }
 
+// RecoverAt interpolates in the exponent the value of the polynomial at x =
+// at from t of the given public shares, which do not need to come from p,
+// only to share its group. The x-coordinates follow the convention of Eval,
+// where the share of index i holds p(i+1): at = 0 gives the same point as
+// RecoverCommit and at = i+1 the same point as Eval(i).
+func (p *PubPoly) RecoverAt(shares []*PubShare, t, at int) (kyber.Point, error) {
+	x, y := xyCommit(p.g, shares, t, len(shares))
+	if len(x) < t {
+		return nil, errors.New("share: not enough good public shares to interpolate")
+	}
+	indices := make([]int, 0, len(x))
+	for i := range x {
+		indices = append(indices, i)
+	}
+	coeffs, err := LagrangeCoefficients(p.g, indices, at)
+	if err != nil {
+		return nil, err
+	}
+	acc := p.g.Point().Null()
+	tmp := p.g.Point()
+	for _, i := range indices {
+		acc.Add(acc, tmp.Mul(coeffs[i], y[i]))
+	}
+	return acc, nil
+}
+
 // RecoverPubPoly reconstructs the full public polynomial from a set of public
 // shares using Lagrange interpolation.
 func RecoverPubPoly(g kyber.Group, shares []*PubShare, t, n int) (*PubPoly, error) {
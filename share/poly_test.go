@@ -163,6 +163,30 @@ func TestPublicRecovery(test *testing.T) {
 	require.True(test, pubPoly.Equal(polyRecovered))
 }
 
+func TestPublicRecoverAt(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	n := 10
+	t := n/2 + 1
+
+	priPoly := NewPriPoly(g, t, nil, g.RandomStream())
+	pubPoly := priPoly.Commit(nil)
+	pubShares := pubPoly.Shares(n)
+	selected := pubShares[n-t:]
+
+	key, err := pubPoly.RecoverAt(selected, t, 0)
+	require.NoError(test, err)
+	require.True(test, key.Equal(pubPoly.Commit()))
+
+	// the share of index 4 is not among the selected ones
+	sub, err := pubPoly.RecoverAt(selected, t, 5)
+	require.NoError(test, err)
+	require.True(test, sub.Equal(pubPoly.Eval(4).V))
+	require.False(test, sub.Equal(key))
+
+	_, err = pubPoly.RecoverAt(selected[1:], t, 5)
+	require.Error(test, err)
+}
+
 func TestPublicRecoveryOutIndex(test *testing.T) {
 	g := edwards25519.NewBlakeSHA256Ed25519()
 	n := 10
@@ -373,6 +397,61 @@ func TestRecoverPriPoly(test *testing.T) {
 	}
 }
 
+func TestRecoverCoefficients(test *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	n := 10
+	t := n/2 + 1
+	coeffs := make([]kyber.Scalar, t)
+	for i := range coeffs {
+		coeffs[i] = suite.Scalar().SetInt64(int64(3*i + 7))
+	}
+	a := CoefficientsToPriPoly(suite, coeffs)
+	shares := a.Shares(n)
+
+	recovered, err := RecoverCoefficients(suite, shares[n-t:], t, n)
+	require.NoError(test, err)
+	require.Len(test, recovered, t)
+	for i := range coeffs {
+		require.True(test, coeffs[i].Equal(recovered[i]))
+	}
+
+	_, err = RecoverCoefficients(suite, shares[:t-1], t, n)
+	require.Error(test, err)
+}
+
+func TestLagrangeCoefficients(test *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	n := 10
+	t := n/2 + 1
+	poly := NewPriPoly(suite, t, nil, suite.RandomStream())
+	shares := poly.Shares(n)
+	indices := []int{1, 3, 4, 6, 8, 9}
+
+	// weight the shares manually to recover the secret
+	coeffs, err := LagrangeCoefficients(suite, indices, 0)
+	require.NoError(test, err)
+	require.Len(test, coeffs, len(indices))
+	secret := suite.Scalar().Zero()
+	for _, i := range indices {
+		secret.Add(secret, suite.Scalar().Mul(coeffs[i], shares[i].V))
+	}
+	require.True(test, secret.Equal(poly.Secret()))
+
+	// or any other share, here the one at index 2
+	coeffs, err = LagrangeCoefficients(suite, indices, 3)
+	require.NoError(test, err)
+	v := suite.Scalar().Zero()
+	for _, i := range indices {
+		v.Add(v, suite.Scalar().Mul(coeffs[i], shares[i].V))
+	}
+	require.True(test, v.Equal(shares[2].V))
+
+	_, err = LagrangeCoefficients(suite, []int{1, 2, 1}, 0)
+	require.Error(test, err)
+	_, err = LagrangeCoefficients(suite, []int{-1, 2}, 0)
+	require.Error(test, err)
+}
+
 func TestPriPolyCoefficients(test *testing.T) {
 	suite := edwards25519.NewBlakeSHA256Ed25519()
 	n := 10
@@ -0,0 +1,286 @@
+// Package beacon implements a publicly verifiable randomness beacon in which
+// several dealers jointly share a secret with the PVSS scheme of package
+// share/pvss, without any interaction between them.
+//
+// Every dealer posts a single Dealing on a public board: the commitments to
+// a random polynomial with respect to a base point H, and for every node i
+// the share f(i) encrypted to the node's public key X_i, as f(i)*X_i, with a
+// NIZK proof that it matches the commitments. Anyone can verify a Dealing
+// with VerifyDealing, without any secret and without interacting with the
+// dealer, so there are no complaints nor justifications. Aggregate then
+// combines the valid dealings into the same Result for every observer.
+//
+// The shared secret is the group element S = s*G, where s is the sum of the
+// secrets of the qualified dealers. Node i only ever learns s_i*G, never a
+// scalar share, so unlike the keys of share/dkg the secret cannot be used
+// for threshold signing or decryption. Node i reveals s_i*G with
+// DecryptShare, along with a proof of correct decryption which anyone can
+// check with VerifyDecShare, and any t valid decrypted shares give S with
+// RecoverSecret, which can be hashed into the output of the beacon.
+//
+// The longterm key of a node is used both as the Schnorr key signing its
+// dealing and as the PVSS key decrypting its shares. The signature covers a
+// hash labelled as a dealing while the proofs of decryption are DLEQ proofs
+// over points, so one cannot be replayed as the other.
+package beacon
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/proof/dleq"
+	"go.dedis.ch/kyber/v3/share"
+	"go.dedis.ch/kyber/v3/share/pvss"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+// Suite describes the functionalities needed by this package.
+type Suite pvss.Suite
+
+// Dealing is the message a dealer posts on the board.
+type Dealing struct {
+	// Index of the dealer in the list of nodes
+	Index uint32
+	// Commitments to the polynomial of the dealer with respect to H
+	Commits []kyber.Point
+	// Encrypted share of each node, with its proof of correct encryption
+	Shares []*pvss.PubVerShare
+	// Schnorr signature of the dealer over the hash of the dealing
+	Signature []byte
+}
+
+// NewDealing returns the signed dealing of the node with the longterm key,
+// sharing a fresh random secret with threshold t among nodes.
+func NewDealing(suite Suite, H kyber.Point, longterm kyber.Scalar, nodes []kyber.Point, t int) (*Dealing, error) {
+	pub := suite.Point().Mul(longterm, nil)
+	index := -1
+	for i, n := range nodes {
+		if n.Equal(pub) {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, errors.New("beacon: public key not found in the list of nodes")
+	}
+
+	secret := suite.Scalar().Pick(suite.RandomStream())
+	shares, pubPoly, err := pvss.EncShares(suite, H, nodes, secret, t)
+	if err != nil {
+		return nil, err
+	}
+	_, commits := pubPoly.Info()
+	d := &Dealing{
+		Index:   uint32(index),
+		Commits: commits,
+		Shares:  shares,
+	}
+	h, err := d.Hash(suite)
+	if err != nil {
+		return nil, err
+	}
+	d.Signature, err = schnorr.Sign(suite, longterm, h)
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// errMalformed is returned for a dealing with a missing share, commitment or
+// proof element.
+var errMalformed = errors.New("beacon: malformed dealing")
+
+// Hash returns the hash of the dealing without its signature. It returns an
+// error if a share, a commitment or an element of a proof is missing.
+func (d *Dealing) Hash(suite Suite) ([]byte, error) {
+	h := suite.Hash()
+	_, _ = h.Write([]byte("pvss beacon dealing"))
+	_ = binary.Write(h, binary.LittleEndian, d.Index)
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(d.Commits)))
+	for _, c := range d.Commits {
+		if c == nil {
+			return nil, errMalformed
+		}
+		if _, err := c.MarshalTo(h); err != nil {
+			return nil, err
+		}
+	}
+	_ = binary.Write(h, binary.LittleEndian, uint32(len(d.Shares)))
+	for _, s := range d.Shares {
+		if s == nil || s.S.V == nil || s.P.C == nil || s.P.R == nil || s.P.VG == nil || s.P.VH == nil {
+			return nil, errMalformed
+		}
+		_ = binary.Write(h, binary.LittleEndian, uint32(s.S.I))
+		for _, m := range []kyber.Marshaling{s.S.V, s.P.C, s.P.R, s.P.VG, s.P.VH} {
+			if _, err := m.MarshalTo(h); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return h.Sum(nil), nil
+}
+
+// VerifyDealing checks publicly that the dealing is signed by its dealer,
+// that it commits to a polynomial of t coefficients, and that every node
+// received an encrypted share consistent with the commitments.
+func VerifyDealing(suite Suite, H kyber.Point, nodes []kyber.Point, t int, d *Dealing) error {
+	if d == nil {
+		return errors.New("beacon: nil dealing")
+	}
+	if d.Index >= uint32(len(nodes)) {
+		return errors.New("beacon: dealer index out of bounds")
+	}
+	h, err := d.Hash(suite)
+	if err != nil {
+		return err
+	}
+	if err := schnorr.Verify(suite, nodes[d.Index], h, d.Signature); err != nil {
+		return err
+	}
+	if len(d.Commits) != t {
+		return errors.New("beacon: number of commitments does not match the threshold")
+	}
+	if len(d.Shares) != len(nodes) {
+		return errors.New("beacon: number of shares does not match the number of nodes")
+	}
+	pubPoly := share.NewPubPoly(suite, H, d.Commits)
+	for i, s := range d.Shares {
+		if s == nil || s.S.I != i {
+			return fmt.Errorf("beacon: invalid index for the share of node %d", i)
+		}
+		if err := pvss.VerifyEncShare(suite, H, nodes[i], pubPoly.Eval(i).V, s); err != nil {
+			return fmt.Errorf("beacon: share of node %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// Result is the outcome of the dealings, which every observer of the board
+// computes identically from the same dealings.
+type Result struct {
+	// QUAL is the list of the dealers whose dealing is valid, in increasing
+	// order.
+	QUAL []int
+	// Public is the sum of the polynomials of the qualified dealers,
+	// committed with respect to H. Its commit is s*H.
+	Public *share.PubPoly
+	// EncShares holds the share of each node encrypted to its public key.
+	EncShares []*share.PubShare
+
+	nodes []kyber.Point
+}
+
+// Aggregate verifies the dealings and combines the valid ones into a
+// Result. Invalid and nil dealings are ignored, and so are copies of a valid
+// dealing, even with another signature. A dealer that signed two different
+// valid dealings is excluded from QUAL, whatever the order of the dealings,
+// so that the Result does not depend on which one an observer saw first.
+// An error is returned if fewer than t dealers have a valid dealing.
+func Aggregate(suite Suite, H kyber.Point, nodes []kyber.Point, t int, dealings []*Dealing) (*Result, error) {
+	valid := make(map[uint32]*Dealing)
+	hashes := make(map[uint32][]byte)
+	equivocating := make(map[uint32]bool)
+	for _, d := range dealings {
+		if d == nil || equivocating[d.Index] {
+			continue
+		}
+		h, err := d.Hash(suite)
+		if err != nil {
+			continue
+		}
+		if prev, ok := hashes[d.Index]; ok && bytes.Equal(prev, h) {
+			continue
+		}
+		if VerifyDealing(suite, H, nodes, t, d) != nil {
+			continue
+		}
+		if _, ok := valid[d.Index]; ok {
+			equivocating[d.Index] = true
+			delete(valid, d.Index)
+			continue
+		}
+		valid[d.Index] = d
+		hashes[d.Index] = h
+	}
+	if len(valid) < t {
+		return nil, fmt.Errorf("beacon: %d valid dealings, need %d", len(valid), t)
+	}
+
+	r := &Result{
+		EncShares: make([]*share.PubShare, len(nodes)),
+		nodes:     nodes,
+	}
+	for i := range nodes {
+		r.EncShares[i] = &share.PubShare{I: i, V: suite.Point().Null()}
+	}
+	for i := range nodes {
+		d, ok := valid[uint32(i)]
+		if !ok {
+			continue
+		}
+		r.QUAL = append(r.QUAL, i)
+		pubPoly := share.NewPubPoly(suite, H, d.Commits)
+		if r.Public == nil {
+			r.Public = pubPoly
+		} else {
+			var err error
+			if r.Public, err = r.Public.Add(pubPoly); err != nil {
+				return nil, err
+			}
+		}
+		for j, s := range d.Shares {
+			r.EncShares[j].V.Add(r.EncShares[j].V, s.S.V)
+		}
+	}
+	return r, nil
+}
+
+// DecryptShare returns the decrypted share s_i*G of the node with the
+// longterm key, with a proof that it is the decryption of its encrypted
+// share.
+func (r *Result) DecryptShare(suite Suite, longterm kyber.Scalar) (*pvss.PubVerShare, error) {
+	pub := suite.Point().Mul(longterm, nil)
+	for i, n := range r.nodes {
+		if !n.Equal(pub) {
+			continue
+		}
+		V := suite.Point().Mul(suite.Scalar().Inv(longterm), r.EncShares[i].V)
+		P, _, _, err := dleq.NewDLEQProof(suite, suite.Point().Base(), V, longterm)
+		if err != nil {
+			return nil, err
+		}
+		return &pvss.PubVerShare{S: share.PubShare{I: i, V: V}, P: *P}, nil
+	}
+	return nil, errors.New("beacon: public key not found in the list of nodes")
+}
+
+// VerifyDecShare checks the proof of a share decrypted with DecryptShare.
+func (r *Result) VerifyDecShare(suite Suite, dec *pvss.PubVerShare) error {
+	if dec == nil {
+		return errors.New("beacon: nil decrypted share")
+	}
+	if dec.S.I < 0 || dec.S.I >= len(r.nodes) {
+		return errors.New("beacon: share index out of bounds")
+	}
+	enc := &pvss.PubVerShare{S: *r.EncShares[dec.S.I]}
+	return pvss.VerifyDecShare(suite, suite.Point().Base(), r.nodes[dec.S.I], enc, dec)
+}
+
+// RecoverSecret verifies the decrypted shares and recovers the shared
+// secret s*G from t valid ones. Invalid and nil shares are ignored.
+func (r *Result) RecoverSecret(suite Suite, decShares []*pvss.PubVerShare) (kyber.Point, error) {
+	var shares []*share.PubShare
+	for _, dec := range decShares {
+		if r.VerifyDecShare(suite, dec) == nil {
+			shares = append(shares, &share.PubShare{I: dec.S.I, V: dec.S.V})
+		}
+	}
+	t := r.Public.Threshold()
+	if len(shares) < t {
+		return nil, errors.New("beacon: not enough valid decrypted shares")
+	}
+	return share.RecoverCommit(suite, shares, t, len(r.nodes))
+}
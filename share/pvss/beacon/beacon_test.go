@@ -0,0 +1,186 @@
+package beacon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/share/pvss"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+var suite = edwards25519.NewBlakeSHA256Ed25519()
+
+func TestBeacon(t *testing.T) {
+	n, th := 4, 3
+	H := suite.Point().Pick(suite.XOF([]byte("H")))
+	secrets := make([]kyber.Scalar, n)
+	nodes := make([]kyber.Point, n)
+	for i := range nodes {
+		secrets[i] = suite.Scalar().Pick(suite.RandomStream())
+		nodes[i] = suite.Point().Mul(secrets[i], nil)
+	}
+
+	// every node posts its dealing on the board
+	var board []*Dealing
+	for i := range nodes {
+		d, err := NewDealing(suite, H, secrets[i], nodes, th)
+		require.NoError(t, err)
+		board = append(board, d)
+	}
+
+	// a public verifier checks every dealing without any secret
+	for _, d := range board {
+		require.NoError(t, VerifyDealing(suite, H, nodes, th, d))
+	}
+
+	// a dealing with a share inconsistent with the commitments
+	bad := *board[3]
+	bad.Shares = append([]*pvss.PubVerShare{}, board[3].Shares...)
+	wrong := *bad.Shares[1]
+	wrong.S.V = suite.Point().Pick(suite.RandomStream())
+	bad.Shares[1] = &wrong
+	require.Error(t, VerifyDealing(suite, H, nodes, th, &bad))
+	// which is not fixed by signing it again
+	h, err := bad.Hash(suite)
+	require.NoError(t, err)
+	bad.Signature, err = schnorr.Sign(suite, secrets[3], h)
+	require.NoError(t, err)
+	require.Error(t, VerifyDealing(suite, H, nodes, th, &bad))
+	// a dealing claimed by another dealer
+	stolen := *board[2]
+	stolen.Index = 1
+	require.Error(t, VerifyDealing(suite, H, nodes, th, &stolen))
+
+	// every observer computes the same result, without the bad dealing
+	res, err := Aggregate(suite, H, nodes, th, append(board[:3:3], &bad))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, res.QUAL)
+	full, err := Aggregate(suite, H, nodes, th, board)
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3}, full.QUAL)
+	_, err = Aggregate(suite, H, nodes, th, board[:2])
+	require.Error(t, err)
+
+	// the nodes decrypt their shares, which anyone can verify
+	decShares := make([]*pvss.PubVerShare, n)
+	for i := range nodes {
+		dec, err := res.DecryptShare(suite, secrets[i])
+		require.NoError(t, err)
+		require.Equal(t, i, dec.S.I)
+		require.NoError(t, res.VerifyDecShare(suite, dec))
+		decShares[i] = dec
+	}
+	forged := *decShares[0]
+	forged.S.V = suite.Point().Pick(suite.RandomStream())
+	require.Error(t, res.VerifyDecShare(suite, &forged))
+
+	// any t shares give the same secret
+	S1, err := res.RecoverSecret(suite, decShares[:th])
+	require.NoError(t, err)
+	S2, err := res.RecoverSecret(suite, decShares[1:])
+	require.NoError(t, err)
+	require.True(t, S1.Equal(S2))
+	S3, err := res.RecoverSecret(suite, []*pvss.PubVerShare{&forged, decShares[1], decShares[2], decShares[3]})
+	require.NoError(t, err)
+	require.True(t, S1.Equal(S3))
+	_, err = res.RecoverSecret(suite, []*pvss.PubVerShare{&forged, decShares[1], decShares[2]})
+	require.Error(t, err)
+
+	// nil shares are ignored
+	require.Error(t, res.VerifyDecShare(suite, nil))
+	S4, err := res.RecoverSecret(suite, []*pvss.PubVerShare{nil, decShares[1], nil, decShares[2], decShares[3]})
+	require.NoError(t, err)
+	require.True(t, S1.Equal(S4))
+}
+
+func TestAggregateEquivocation(t *testing.T) {
+	n, th := 4, 3
+	H := suite.Point().Pick(suite.XOF([]byte("H")))
+	secrets := make([]kyber.Scalar, n)
+	nodes := make([]kyber.Point, n)
+	for i := range nodes {
+		secrets[i] = suite.Scalar().Pick(suite.RandomStream())
+		nodes[i] = suite.Point().Mul(secrets[i], nil)
+	}
+	var board []*Dealing
+	for i := range nodes {
+		d, err := NewDealing(suite, H, secrets[i], nodes, th)
+		require.NoError(t, err)
+		board = append(board, d)
+	}
+
+	// nil dealings are ignored
+	require.Error(t, VerifyDealing(suite, H, nodes, th, nil))
+	res, err := Aggregate(suite, H, nodes, th, append([]*Dealing{nil}, board...))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3}, res.QUAL)
+
+	// a copy of a dealing signed again is not an equivocation
+	copied := *board[0]
+	h, err := copied.Hash(suite)
+	require.NoError(t, err)
+	copied.Signature, err = schnorr.Sign(suite, secrets[0], h)
+	require.NoError(t, err)
+	res, err = Aggregate(suite, H, nodes, th, append(board, &copied))
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3}, res.QUAL)
+
+	// dealer 0 posts a second valid dealing, and is excluded in whatever
+	// order the dealings are seen
+	other, err := NewDealing(suite, H, secrets[0], nodes, th)
+	require.NoError(t, err)
+	require.NoError(t, VerifyDealing(suite, H, nodes, th, other))
+	first, err := Aggregate(suite, H, nodes, th, append(board, other))
+	require.NoError(t, err)
+	last, err := Aggregate(suite, H, nodes, th, append([]*Dealing{other}, board...))
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2, 3}, first.QUAL)
+	require.Equal(t, first.QUAL, last.QUAL)
+	require.True(t, first.Public.Commit().Equal(last.Public.Commit()))
+}
+
+func TestMalformedDealing(t *testing.T) {
+	n, th := 4, 3
+	H := suite.Point().Pick(suite.XOF([]byte("H")))
+	secrets := make([]kyber.Scalar, n)
+	nodes := make([]kyber.Point, n)
+	for i := range nodes {
+		secrets[i] = suite.Scalar().Pick(suite.RandomStream())
+		nodes[i] = suite.Point().Mul(secrets[i], nil)
+	}
+	var board []*Dealing
+	for i := range nodes {
+		d, err := NewDealing(suite, H, secrets[i], nodes, th)
+		require.NoError(t, err)
+		board = append(board, d)
+	}
+
+	// copies of the dealing of node 3 with a missing element
+	malformed := func(f func(d *Dealing)) *Dealing {
+		d := *board[3]
+		d.Commits = append([]kyber.Point{}, board[3].Commits...)
+		d.Shares = make([]*pvss.PubVerShare, len(board[3].Shares))
+		for i, s := range board[3].Shares {
+			c := *s
+			d.Shares[i] = &c
+		}
+		f(&d)
+		return &d
+	}
+	for _, d := range []*Dealing{
+		malformed(func(d *Dealing) { d.Shares[1] = nil }),
+		malformed(func(d *Dealing) { d.Shares[1].S.V = nil }),
+		malformed(func(d *Dealing) { d.Shares[1].P.VH = nil }),
+		malformed(func(d *Dealing) { d.Shares[1].P.C = nil }),
+		malformed(func(d *Dealing) { d.Commits[0] = nil }),
+	} {
+		require.Error(t, VerifyDealing(suite, H, nodes, th, d))
+		res, err := Aggregate(suite, H, nodes, th, append(board[:3:3], d))
+		require.NoError(t, err)
+		require.Equal(t, []int{0, 1, 2}, res.QUAL)
+		_, err = Aggregate(suite, H, nodes, th, append(board[:2:2], d))
+		require.Error(t, err)
+	}
+}
@@ -0,0 +1,56 @@
+package share
+
+import (
+	"crypto/cipher"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// NewZeroShares creates a fresh random polynomial of threshold t whose
+// constant term is zero and returns its n private shares along with the
+// public commitment to it. Each member of a committee can deal such a sharing
+// of zero to the others; adding all the received zero shares to an existing
+// share with RefreshPriShare refreshes the sharing while leaving the shared
+// secret unchanged.
+func NewZeroShares(g kyber.Group, t, n int, rand cipher.Stream) ([]*PriShare, *PubPoly) {
+	poly := NewPriPoly(g, t, g.Scalar().Zero(), rand)
+	return poly.Shares(n), poly.Commit(nil)
+}
+
+// RefreshPriShare adds the given shares of zero to the private share s and
+// returns the result as a new share. All zero shares must have the same index
+// as s. Zero shares can be checked beforehand against their dealer's
+// commitment with PubPoly.Check, and the commitment itself must commit to the
+// identity element, i.e. PubPoly.Commit() must be the null point.
+func RefreshPriShare(g kyber.Group, s *PriShare, zeros []*PriShare) (*PriShare, error) {
+	v := g.Scalar().Set(s.V)
+	for _, z := range zeros {
+		if z == nil || z.V == nil {
+			return nil, errors.New("share: nil zero share")
+		}
+		if z.I != s.I {
+			return nil, errors.New("share: zero share index does not match")
+		}
+		v.Add(v, z.V)
+	}
+	return &PriShare{I: s.I, V: v}, nil
+}
+
+// RefreshPubPoly adds the given commitments to sharings of zero to the
+// public commitment polynomial p. The result is the commitment matching the
+// shares returned by RefreshPriShare.
+func RefreshPubPoly(p *PubPoly, zeros []*PubPoly) (*PubPoly, error) {
+	null := p.g.Point().Null()
+	acc := p
+	for _, z := range zeros {
+		if !z.Commit().Equal(null) {
+			return nil, errors.New("share: commitment is not a sharing of zero")
+		}
+		var err error
+		if acc, err = acc.Add(z); err != nil {
+			return nil, err
+		}
+	}
+	return acc, nil
+}
@@ -0,0 +1,58 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+func TestRefreshZeroShares(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	n := 7
+	t := n/2 + 1
+
+	priPoly := NewPriPoly(g, t, nil, g.RandomStream())
+	pubPoly := priPoly.Commit(nil)
+	shares := priPoly.Shares(n)
+
+	// every member deals a sharing of zero to the committee
+	zeroShares := make([][]*PriShare, n)
+	zeroCommits := make([]*PubPoly, n)
+	for i := 0; i < n; i++ {
+		zeroShares[i], zeroCommits[i] = NewZeroShares(g, t, n, g.RandomStream())
+		require.True(test, zeroCommits[i].Commit().Equal(g.Point().Null()))
+	}
+
+	refreshed := make([]*PriShare, n)
+	for i := 0; i < n; i++ {
+		received := make([]*PriShare, n)
+		for j := 0; j < n; j++ {
+			received[j] = zeroShares[j][i]
+			require.True(test, zeroCommits[j].Check(received[j]))
+		}
+		s, err := RefreshPriShare(g, shares[i], received)
+		require.NoError(test, err)
+		require.False(test, s.V.Equal(shares[i].V))
+		refreshed[i] = s
+	}
+
+	newPubPoly, err := RefreshPubPoly(pubPoly, zeroCommits)
+	require.NoError(test, err)
+	require.True(test, newPubPoly.Commit().Equal(pubPoly.Commit()))
+	for _, s := range refreshed {
+		require.True(test, newPubPoly.Check(s))
+	}
+
+	secret, err := RecoverSecret(g, refreshed[n-t:], t, n)
+	require.NoError(test, err)
+	require.True(test, secret.Equal(priPoly.Secret()))
+
+	// mismatching indices are rejected
+	_, err = RefreshPriShare(g, shares[0], []*PriShare{zeroShares[0][1]})
+	require.Error(test, err)
+
+	// a commitment to a non-zero secret is rejected
+	_, err = RefreshPubPoly(pubPoly, []*PubPoly{pubPoly})
+	require.Error(test, err)
+}
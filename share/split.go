@@ -0,0 +1,22 @@
+package share
+
+import (
+	"crypto/cipher"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Split shares an existing secret among n participants so that any t of them
+// can reconstruct it with Combine. It returns the n private shares together
+// with the public commitment polynomial against which each share can be
+// checked. Unlike a DKG, the caller acts as a trusted dealer: it knows the
+// secret and every share, and must erase them once they have been handed out.
+func Split(g kyber.Group, secret kyber.Scalar, t, n int, rand cipher.Stream) ([]*PriShare, *PubPoly) {
+	poly := NewPriPoly(g, t, secret, rand)
+	return poly.Shares(n), poly.Commit(nil)
+}
+
+// Combine recovers the secret shared by Split from at least t of its shares.
+func Combine(g kyber.Group, shares []*PriShare, t, n int) (kyber.Scalar, error) {
+	return RecoverSecret(g, shares, t, n)
+}
@@ -0,0 +1,30 @@
+package share
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+)
+
+func TestSplitCombine(test *testing.T) {
+	g := edwards25519.NewBlakeSHA256Ed25519()
+	n := 10
+	t := n/2 + 1
+	secret := g.Scalar().SetInt64(123456789)
+	public := g.Point().Mul(secret, nil)
+
+	shares, pubPoly := Split(g, secret, t, n, g.RandomStream())
+	require.Len(test, shares, n)
+	require.True(test, pubPoly.Commit().Equal(public))
+	for _, s := range shares {
+		require.True(test, pubPoly.Check(s))
+	}
+
+	recovered, err := Combine(g, shares[n-t:], t, n)
+	require.NoError(test, err)
+	require.True(test, recovered.Equal(secret))
+
+	_, err = Combine(g, shares[:t-1], t, n)
+	require.Error(test, err)
+}
@@ -0,0 +1,220 @@
+package vss
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/share"
+)
+
+// The binary encodings below are deterministic: variable-length fields are
+// prefixed with their length as a 32-bit little-endian integer, and points and
+// scalars use their fixed-size encoding of the suite. Decoding a deal or a
+// justification requires the suite to build the points and scalars, so their
+// Decode methods take it as an argument. The methods are not named
+// MarshalBinary and UnmarshalBinary on purpose: go.dedis.ch/protobuf would
+// pick them up and change the protobuf encoding of these messages, which is
+// the one used inside encrypted deals and by the hash of a justification.
+
+// errInvalidEncoding is returned when a buffer is too short or too long.
+var errInvalidEncoding = errors.New("vss: invalid encoding")
+
+// Encode returns the encoding of the session identifier, share,
+// threshold and commitments of the deal.
+func (d *Deal) Encode() ([]byte, error) {
+	if d.SecShare == nil || d.SecShare.V == nil {
+		return nil, errors.New("vss: deal without share")
+	}
+	var b bytes.Buffer
+	writeBytes(&b, d.SessionID)
+	writeUint32(&b, uint32(d.SecShare.I))
+	if _, err := d.SecShare.V.MarshalTo(&b); err != nil {
+		return nil, err
+	}
+	writeUint32(&b, d.T)
+	writeUint32(&b, uint32(len(d.Commitments)))
+	for _, c := range d.Commitments {
+		if c == nil {
+			return nil, ErrInvalidCommitment
+		}
+		if _, err := c.MarshalTo(&b); err != nil {
+			return nil, err
+		}
+	}
+	return b.Bytes(), nil
+}
+
+// Decode decodes a deal encoded with Encode. It returns
+// ErrInvalidCommitment if the commitments do not have the length of a point
+// of the suite or lie outside the prime-order subgroup.
+func (d *Deal) Decode(s Suite, buf []byte) error {
+	r := bytes.NewReader(buf)
+	sid, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+	i, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	v := s.Scalar()
+	if _, err := v.UnmarshalFrom(r); err != nil {
+		return err
+	}
+	t, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	n, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	if uint64(r.Len()) != uint64(n)*uint64(s.PointLen()) {
+		return ErrInvalidCommitment
+	}
+	commits := make([]kyber.Point, n)
+	for j := range commits {
+		commits[j] = s.Point()
+		if _, err := commits[j].UnmarshalFrom(r); err != nil {
+			return err
+		}
+	}
+	if err := checkCommitments(s, commits); err != nil {
+		return err
+	}
+	d.SessionID = sid
+	d.SecShare = &share.PriShare{I: int(i), V: v}
+	d.T = t
+	d.Commitments = commits
+	return nil
+}
+
+// Encode returns the encoding of the session identifier, index,
+// status and signature of the response.
+func (r *Response) Encode() ([]byte, error) {
+	var b bytes.Buffer
+	writeBytes(&b, r.SessionID)
+	writeUint32(&b, r.Index)
+	if r.Status == StatusApproval {
+		b.WriteByte(1)
+	} else {
+		b.WriteByte(0)
+	}
+	writeBytes(&b, r.Signature)
+	return b.Bytes(), nil
+}
+
+// Decode decodes a response encoded with Encode.
+func (r *Response) Decode(buf []byte) error {
+	rd := bytes.NewReader(buf)
+	sid, err := readBytes(rd)
+	if err != nil {
+		return err
+	}
+	index, err := readUint32(rd)
+	if err != nil {
+		return err
+	}
+	status, err := rd.ReadByte()
+	if err != nil || status > 1 {
+		return errInvalidEncoding
+	}
+	sig, err := readBytes(rd)
+	if err != nil {
+		return err
+	}
+	if rd.Len() != 0 {
+		return errInvalidEncoding
+	}
+	r.SessionID = sid
+	r.Index = index
+	r.Status = status == 1
+	r.Signature = sig
+	return nil
+}
+
+// Encode returns the encoding of the session identifier, index, deal
+// and signature of the justification.
+func (j *Justification) Encode() ([]byte, error) {
+	if j.Deal == nil {
+		return nil, errors.New("vss: justification without deal")
+	}
+	deal, err := j.Deal.Encode()
+	if err != nil {
+		return nil, err
+	}
+	var b bytes.Buffer
+	writeBytes(&b, j.SessionID)
+	writeUint32(&b, j.Index)
+	writeBytes(&b, deal)
+	writeBytes(&b, j.Signature)
+	return b.Bytes(), nil
+}
+
+// Decode decodes a justification encoded with Encode.
+func (j *Justification) Decode(s Suite, buf []byte) error {
+	r := bytes.NewReader(buf)
+	sid, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+	index, err := readUint32(r)
+	if err != nil {
+		return err
+	}
+	dealBuf, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+	sig, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return errInvalidEncoding
+	}
+	deal := new(Deal)
+	if err := deal.Decode(s, dealBuf); err != nil {
+		return err
+	}
+	j.SessionID = sid
+	j.Index = index
+	j.Deal = deal
+	j.Signature = sig
+	return nil
+}
+
+func writeUint32(b *bytes.Buffer, v uint32) {
+	var buf [4]byte
+	binary.LittleEndian.PutUint32(buf[:], v)
+	b.Write(buf[:])
+}
+
+func writeBytes(b *bytes.Buffer, data []byte) {
+	writeUint32(b, uint32(len(data)))
+	b.Write(data)
+}
+
+func readUint32(r *bytes.Reader) (uint32, error) {
+	var buf [4]byte
+	if _, err := io.ReadFull(r, buf[:]); err != nil {
+		return 0, errInvalidEncoding
+	}
+	return binary.LittleEndian.Uint32(buf[:]), nil
+}
+
+func readBytes(r *bytes.Reader) ([]byte, error) {
+	l, err := readUint32(r)
+	if err != nil {
+		return nil, err
+	}
+	if uint64(l) > uint64(r.Len()) {
+		return nil, errInvalidEncoding
+	}
+	data := make([]byte, l)
+	_, _ = io.ReadFull(r, data)
+	return data, nil
+}
@@ -0,0 +1,122 @@
+package vss
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/protobuf"
+)
+
+func TestDealEncode(t *testing.T) {
+	dealer := genDealer()
+	d, err := dealer.PlaintextDeal(0)
+	require.NoError(t, err)
+
+	buf, err := d.Encode()
+	require.NoError(t, err)
+	dec := new(Deal)
+	require.NoError(t, dec.Decode(suite, buf))
+	require.Equal(t, d.SessionID, dec.SessionID)
+	require.Equal(t, d.SecShare.I, dec.SecShare.I)
+	require.True(t, d.SecShare.V.Equal(dec.SecShare.V))
+	require.Equal(t, d.T, dec.T)
+	require.Len(t, dec.Commitments, len(d.Commitments))
+	for i := range d.Commitments {
+		require.True(t, d.Commitments[i].Equal(dec.Commitments[i]))
+	}
+	buf2, err := dec.Encode()
+	require.NoError(t, err)
+	require.Equal(t, buf, buf2)
+
+	// a commitment one byte short, or with a trailing byte
+	require.Equal(t, ErrInvalidCommitment, new(Deal).Decode(suite, buf[:len(buf)-1]))
+	require.Equal(t, ErrInvalidCommitment, new(Deal).Decode(suite, append(buf, 0)))
+	require.Error(t, new(Deal).Decode(suite, buf[:10]))
+}
+
+func TestResponseEncode(t *testing.T) {
+	for _, status := range []bool{StatusApproval, StatusComplaint} {
+		r := &Response{
+			SessionID: []byte("session"),
+			Index:     3,
+			Status:    status,
+			Signature: randomBytes(64),
+		}
+		buf, err := r.Encode()
+		require.NoError(t, err)
+		dec := new(Response)
+		require.NoError(t, dec.Decode(buf))
+		require.Equal(t, r, dec)
+		require.Error(t, new(Response).Decode(buf[:len(buf)-1]))
+		require.Error(t, new(Response).Decode(append(buf, 0)))
+	}
+}
+
+func TestJustificationEncode(t *testing.T) {
+	dealer := genDealer()
+	d, err := dealer.PlaintextDeal(1)
+	require.NoError(t, err)
+	j := &Justification{
+		SessionID: dealer.SessionID(),
+		Index:     1,
+		Deal:      d,
+		Signature: randomBytes(64),
+	}
+	buf, err := j.Encode()
+	require.NoError(t, err)
+	dec := new(Justification)
+	require.NoError(t, dec.Decode(suite, buf))
+	require.Equal(t, j.SessionID, dec.SessionID)
+	require.Equal(t, j.Index, dec.Index)
+	require.Equal(t, j.Signature, dec.Signature)
+	require.Equal(t, j.Hash(suite), dec.Hash(suite))
+	require.Error(t, new(Justification).Decode(suite, buf[:len(buf)-1]))
+}
+
+// The binary encodings must not change the protobuf encoding of the
+// messages, which is used inside encrypted deals and by the hash of a
+// justification.
+func TestMessagesProtobuf(t *testing.T) {
+	var point kyber.Point
+	var secret kyber.Scalar
+	constructors := make(protobuf.Constructors)
+	constructors[reflect.TypeOf(&point).Elem()] = func() interface{} { return suite.Point() }
+	constructors[reflect.TypeOf(&secret).Elem()] = func() interface{} { return suite.Scalar() }
+
+	dealer := genDealer()
+	d, err := dealer.PlaintextDeal(1)
+	require.NoError(t, err)
+	buf, err := protobuf.Encode(d)
+	require.NoError(t, err)
+	decD := new(Deal)
+	require.NoError(t, protobuf.DecodeWithConstructors(buf, decD, constructors))
+	require.True(t, d.SecShare.V.Equal(decD.SecShare.V))
+	require.Equal(t, d.Commitments[0].String(), decD.Commitments[0].String())
+
+	r := &Response{
+		SessionID: []byte("session"),
+		Index:     3,
+		Status:    StatusComplaint,
+		Signature: randomBytes(64),
+	}
+	buf, err = protobuf.Encode(r)
+	require.NoError(t, err)
+	decR := new(Response)
+	require.NoError(t, protobuf.Decode(buf, decR))
+	require.Equal(t, r, decR)
+
+	j := &Justification{
+		SessionID: dealer.SessionID(),
+		Index:     1,
+		Deal:      d,
+		Signature: randomBytes(64),
+	}
+	buf, err = protobuf.Encode(j)
+	require.NoError(t, err)
+	decJ := new(Justification)
+	require.NoError(t, protobuf.DecodeWithConstructors(buf, decJ, constructors))
+	require.Equal(t, j.Signature, decJ.Signature)
+	require.Equal(t, j.Hash(suite), decJ.Hash(suite))
+}
@@ -392,6 +392,13 @@ func (v *Verifier) ProcessEncryptedDeal(e *EncryptedDeal) (*Response, error) {
 	return r, nil
 }
 
+// DecryptDeal decrypts the deal received from the Dealer without verifying it
+// nor changing the state of the verifier. The returned deal can then be
+// checked with VerifyDeal.
+func (v *Verifier) DecryptDeal(e *EncryptedDeal) (*Deal, error) {
+	return v.decryptDeal(e)
+}
+
 func (v *Verifier) decryptDeal(e *EncryptedDeal) (*Deal, error) {
 	// verify signature
 	if err := schnorr.Verify(v.suite, v.dealer, e.DHKey, e.Signature); err != nil {
@@ -435,8 +442,12 @@ func (v *Verifier) ProcessResponse(resp *Response) error {
 
 // Commits returns the commitments of the coefficients of the polynomial
 // contained in the Deal received. It is public information. The private
-// information in the deal must be retrieved through Deal().
+// information in the deal must be retrieved through Deal(). It returns nil if
+// no deal has been received.
 func (v *Verifier) Commits() []kyber.Point {
+	if v.deal == nil {
+		return nil
+	}
 	return v.deal.Commitments
 }
 
@@ -552,6 +563,10 @@ func NewEmptyAggregator(suite Suite, verifiers []kyber.Point) *Aggregator {
 
 var errDealAlreadyProcessed = errors.New("vss: verifier already received a deal")
 
+// ErrInvalidCommitment is returned when a Deal contains a commitment that is
+// not a valid point of the prime-order subgroup.
+var ErrInvalidCommitment = errors.New("vss: invalid commitment in deal")
+
 // VerifyDeal analyzes the deal and returns an error if it's incorrect. If
 // inclusion is true, it also returns an error if it is the second time this struct
 // analyzes a Deal.
@@ -560,17 +575,27 @@ func (a *Aggregator) VerifyDeal(d *Deal, inclusion bool) error {
 		return errDealAlreadyProcessed
 
 	}
+	if err := checkCommitments(a.suite, d.Commitments); err != nil {
+		return err
+	}
 	if a.deal == nil {
 		a.commits = d.Commitments
 		a.sid = d.SessionID
 		a.deal = d
-		a.t = int(d.T)
+		// keep the threshold given to SetThreshold
+		if a.t == 0 {
+			a.t = int(d.T)
+		}
 	}
 
 	if !validT(int(d.T), a.verifiers) {
 		return errors.New("vss: invalid t received in Deal")
 	}
 
+	if len(d.Commitments) != int(d.T) {
+		return errors.New("vss: number of commitments does not match t in Deal")
+	}
+
 	if int(d.T) != a.t {
 		return errors.New("vss: incompatible threshold - potential attack")
 	}
@@ -727,6 +752,29 @@ func validT(t int, verifiers []kyber.Point) bool {
 	return t >= 2 && t <= len(verifiers) && int(uint32(t)) == t
 }
 
+// checkCommitments returns ErrInvalidCommitment if one of the commitments is
+// missing or lies outside the prime-order subgroup. Multiplying a point by the
+// scalar -1 yields (q-1)P, so adding P back gives the identity only when the
+// order of P divides the group order q. This rejects the low-order and
+// mixed-order points a malicious dealer could use on curves with a cofactor.
+func checkCommitments(suite Suite, commits []kyber.Point) error {
+	if len(commits) == 0 {
+		return ErrInvalidCommitment
+	}
+	minusOne := suite.Scalar().Neg(suite.Scalar().One())
+	null := suite.Point().Null()
+	for _, c := range commits {
+		if c == nil {
+			return ErrInvalidCommitment
+		}
+		q := suite.Point().Mul(minusOne, c)
+		if !q.Add(q, c).Equal(null) {
+			return ErrInvalidCommitment
+		}
+	}
+	return nil
+}
+
 func deriveH(suite Suite, verifiers []kyber.Point) kyber.Point {
 	var b bytes.Buffer
 	for _, v := range verifiers {
@@ -744,6 +792,13 @@ func findPub(verifiers []kyber.Point, idx uint32) (kyber.Point, bool) {
 	return verifiers[iidx], true
 }
 
+// SessionID returns the session identifier of a deal from dealer to the
+// given verifiers, with the given commitments and threshold. Responses and
+// justifications carry it, which binds them to the commitments of the deal.
+func SessionID(suite Suite, dealer kyber.Point, verifiers, commitments []kyber.Point, t int) ([]byte, error) {
+	return sessionID(suite, dealer, verifiers, commitments, t)
+}
+
 func sessionID(suite Suite, dealer kyber.Point, verifiers, commitments []kyber.Point, t int) ([]byte, error) {
 	h := suite.Hash()
 	_, _ = dealer.MarshalTo(h)
@@ -776,7 +831,10 @@ func (d *Deal) decode(s Suite, buff []byte) error {
 	var secret kyber.Scalar
 	constructors[reflect.TypeOf(&point).Elem()] = func() interface{} { return s.Point() }
 	constructors[reflect.TypeOf(&secret).Elem()] = func() interface{} { return s.Scalar() }
-	return protobuf.DecodeWithConstructors(buff, d, constructors)
+	if err := protobuf.DecodeWithConstructors(buff, d, constructors); err != nil {
+		return err
+	}
+	return checkCommitments(s, d.Commitments)
 }
 
 // Hash returns the hash of a Justification.
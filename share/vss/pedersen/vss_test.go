@@ -212,6 +212,38 @@ func TestVSSVerifierDecryptDeal(t *testing.T) {
 	encD.Cipher = goodCipher
 }
 
+func TestVSSVerifierDecryptDealInvalidCommitment(t *testing.T) {
+	// point of order 8 on edwards25519
+	lowOrder := suite.Point()
+	require.NoError(t, lowOrder.UnmarshalBinary([]byte{
+		0x26, 0xe8, 0x95, 0x8f, 0xc2, 0xb2, 0x27, 0xb0, 0x45, 0xc3, 0xf4,
+		0x89, 0xf2, 0xef, 0x98, 0xf0, 0xd5, 0xdf, 0xac, 0x05, 0xd3, 0xc6,
+		0x33, 0x39, 0xb1, 0x38, 0x02, 0x88, 0x6d, 0x53, 0xfc, 0x05}))
+	// point of mixed order, i.e. outside of the prime-order subgroup
+	mixedOrder := suite.Point().Add(suite.Point().Base(), lowOrder)
+
+	for _, bad := range []kyber.Point{lowOrder, mixedOrder} {
+		dealer, verifiers := genAll()
+		v := verifiers[0]
+		d := dealer.deals[0]
+		commits := make([]kyber.Point, len(d.Commitments))
+		copy(commits, d.Commitments)
+		commits[1] = bad
+		d.Commitments = commits
+
+		encD, err := dealer.EncryptedDeal(0)
+		require.NoError(t, err)
+		decD, err := v.decryptDeal(encD)
+		require.Equal(t, ErrInvalidCommitment, err)
+		resp, err := v.ProcessEncryptedDeal(encD)
+		require.Equal(t, ErrInvalidCommitment, err)
+		require.Nil(t, resp)
+
+		// the same deal sent in clear in a justification is refused too
+		require.Equal(t, ErrInvalidCommitment, v.VerifyDeal(decD, false))
+	}
+}
+
 func TestVSSVerifierReceiveDeal(t *testing.T) {
 	dealer, verifiers := genAll()
 	v := verifiers[0]
@@ -488,6 +520,14 @@ func TestVSSAggregatorVerifyDeal(t *testing.T) {
 	assert.Error(t, aggr.VerifyDeal(deal, false))
 	deal.T = goodT
 
+	// commitments of a polynomial of another degree
+	goodCommits := deal.Commitments
+	deal.Commitments = append(append([]kyber.Point{}, goodCommits...), suite.Point().Base())
+	assert.Error(t, aggr.VerifyDeal(deal, false))
+	deal.Commitments = goodCommits[:len(goodCommits)-1]
+	assert.Error(t, aggr.VerifyDeal(deal, false))
+	deal.Commitments = goodCommits
+
 	// wrong SessionID
 	goodSid := deal.SessionID
 	deal.SessionID = make([]byte, 32)
@@ -0,0 +1,106 @@
+package bls
+
+import (
+	"crypto/cipher"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
+)
+
+// SchemeMessageAugmentation is the message augmentation variant of BLS
+// bound to a pairing suite. Its methods are the functions SignAugmented,
+// VerifyAugmented and BatchVerifyAugmented, and it implements sign.Scheme.
+type SchemeMessageAugmentation struct {
+	suite pairing.Suite
+}
+
+// NewSchemeMessageAugmentation returns the message augmentation variant of
+// BLS over the given pairing suite.
+func NewSchemeMessageAugmentation(suite pairing.Suite) *SchemeMessageAugmentation {
+	return &SchemeMessageAugmentation{suite: suite}
+}
+
+// NewKeyPair returns a new key pair, as NewKeyPair.
+func (s *SchemeMessageAugmentation) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	return NewKeyPair(s.suite, random)
+}
+
+// Sign signs msg with SignAugmented.
+func (s *SchemeMessageAugmentation) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	return SignAugmented(s.suite, private, msg)
+}
+
+// Verify verifies a signature of Sign with VerifyAugmented.
+func (s *SchemeMessageAugmentation) Verify(public kyber.Point, msg, sig []byte) error {
+	return VerifyAugmented(s.suite, public, msg, sig)
+}
+
+// AggregateSignatures aggregates signatures of Sign, as AggregateSignatures.
+func (s *SchemeMessageAugmentation) AggregateSignatures(sigs ...[]byte) ([]byte, error) {
+	return AggregateSignatures(s.suite, sigs...)
+}
+
+// BatchVerify verifies an aggregate of signatures of Sign with
+// BatchVerifyAugmented.
+func (s *SchemeMessageAugmentation) BatchVerify(publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	return BatchVerifyAugmented(s.suite, publics, msgs, sig)
+}
+
+// The functions below implement the message augmentation variant of BLS
+// described in the IETF BLS signature draft (the "_AUG_" ciphersuites): the
+// signer's public key is prepended to the message before it is hashed. Since
+// the hashed messages of two different signers are always distinct, signatures
+// can be aggregated and verified with BatchVerifyAugmented without being
+// vulnerable to rogue public-key attacks, and without requiring a proof of
+// possession of the keys. Note that the hash to G1 used by this package is not
+// the one of the IETF draft, so the signatures are not interoperable with
+// other implementations of it.
+
+// SignAugmented creates a BLS signature S = x * H(X || m) on a message m
+// using the private key x, where X is the public key matching x.
+func SignAugmented(suite pairing.Suite, x kyber.Scalar, msg []byte) ([]byte, error) {
+	X := suite.G2().Point().Mul(x, nil)
+	augmented, err := augment(X, msg)
+	if err != nil {
+		return nil, err
+	}
+	return Sign(suite, x, augmented)
+}
+
+// VerifyAugmented checks the given BLS signature S on the message m created
+// with SignAugmented by the owner of the public key X.
+func VerifyAugmented(suite pairing.Suite, X kyber.Point, msg, sig []byte) error {
+	augmented, err := augment(X, msg)
+	if err != nil {
+		return err
+	}
+	return Verify(suite, X, augmented, sig)
+}
+
+// BatchVerifyAugmented verifies an aggregate of signatures created with
+// SignAugmented, where msgs[i] has been signed by the owner of publics[i].
+// The signatures are aggregated with AggregateSignatures. Contrary to
+// BatchVerify, different signers can sign the same message. An error is
+// returned if there are not as many public keys as messages.
+func BatchVerifyAugmented(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig []byte) error {
+	if len(publics) != len(msgs) {
+		return errors.New("bls: number of public keys does not match the number of messages")
+	}
+	augmented := make([][]byte, len(msgs))
+	for i := range msgs {
+		var err error
+		if augmented[i], err = augment(publics[i], msgs[i]); err != nil {
+			return err
+		}
+	}
+	return BatchVerify(suite, publics, augmented, sig)
+}
+
+func augment(X kyber.Point, msg []byte) ([]byte, error) {
+	buff, err := X.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(buff, msg...), nil
+}
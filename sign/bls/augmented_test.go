@@ -0,0 +1,96 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestBLSAugmented(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	private1, public1 := NewKeyPair(suite, random.New())
+	private2, public2 := NewKeyPair(suite, random.New())
+
+	sig1, err := SignAugmented(suite, private1, msg)
+	require.NoError(t, err)
+	require.NoError(t, VerifyAugmented(suite, public1, msg, sig1))
+	require.Error(t, VerifyAugmented(suite, public2, msg, sig1))
+	// an augmented signature is not a plain signature
+	require.Error(t, Verify(suite, public1, msg, sig1))
+
+	// both signers can sign the same message
+	sig2, err := SignAugmented(suite, private2, msg)
+	require.NoError(t, err)
+	aggSig, err := AggregateSignatures(suite, sig1, sig2)
+	require.NoError(t, err)
+	require.NoError(t, BatchVerifyAugmented(suite, []kyber.Point{public1, public2}, [][]byte{msg, msg}, aggSig))
+	require.Error(t, BatchVerifyAugmented(suite, []kyber.Point{public2, public1}, [][]byte{msg, []byte("other")}, aggSig))
+}
+
+func TestBLSAugmentedLengths(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	private, public := NewKeyPair(suite, random.New())
+	sig, err := SignAugmented(suite, private, msg)
+	require.NoError(t, err)
+	require.NoError(t, BatchVerifyAugmented(suite, []kyber.Point{public}, [][]byte{msg}, sig))
+	require.Error(t, BatchVerifyAugmented(suite, []kyber.Point{public}, [][]byte{msg, msg}, sig))
+	require.Error(t, BatchVerifyAugmented(suite, []kyber.Point{public, public}, [][]byte{msg}, sig))
+}
+
+func TestBLSSchemeMessageAugmentation(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	var scheme sign.Scheme = NewSchemeMessageAugmentation(suite)
+	aug := scheme.(*SchemeMessageAugmentation)
+	private1, public1 := aug.NewKeyPair(random.New())
+	private2, public2 := aug.NewKeyPair(random.New())
+
+	sig1, err := scheme.Sign(private1, msg)
+	require.NoError(t, err)
+	require.NoError(t, scheme.Verify(public1, msg, sig1))
+	require.NoError(t, VerifyAugmented(suite, public1, msg, sig1))
+	require.Error(t, scheme.Verify(public2, msg, sig1))
+
+	sig2, err := scheme.Sign(private2, msg)
+	require.NoError(t, err)
+	aggSig, err := aug.AggregateSignatures(sig1, sig2)
+	require.NoError(t, err)
+	require.NoError(t, aug.BatchVerify([]kyber.Point{public1, public2}, [][]byte{msg, msg}, aggSig))
+	require.Error(t, aug.BatchVerify([]kyber.Point{public1}, [][]byte{msg, msg}, aggSig))
+}
+
+func TestBLSAugmentedRogueKey(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	_, victim := NewKeyPair(suite, random.New())
+
+	// the attacker publishes rogue = x*G2 - victim, so that the sum of both
+	// keys is x*G2 which the attacker controls
+	x, xG := NewKeyPair(suite, random.New())
+	rogue := suite.G2().Point().Sub(xG, victim)
+
+	// without augmentation, the attacker alone forges a multi-signature of
+	// the victim and itself on msg
+	forged, err := Sign(suite, x, msg)
+	require.NoError(t, err)
+	require.NoError(t, Verify(suite, AggregatePublicKeys(suite, victim, rogue), msg, forged))
+
+	// with augmentation, the same kind of forgery does not verify
+	for _, m := range [][]byte{msg, mustAugment(t, rogue, msg), mustAugment(t, xG, msg)} {
+		forged, err = Sign(suite, x, m)
+		require.NoError(t, err)
+		require.Error(t, BatchVerifyAugmented(suite, []kyber.Point{victim, rogue}, [][]byte{msg, msg}, forged))
+	}
+}
+
+func mustAugment(t *testing.T, X kyber.Point, msg []byte) []byte {
+	buff, err := augment(X, msg)
+	require.NoError(t, err)
+	return buff
+}
@@ -0,0 +1,97 @@
+package bls
+
+import (
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
+)
+
+// SignerBitfield represents the set of signers of an aggregate signature
+// among a known committee of n members: bit i is set when the i-th member
+// signed. Bits are stored least significant first within each byte, which is
+// the same layout as sign.Mask.
+type SignerBitfield struct {
+	n    int
+	bits []byte
+}
+
+// NewSignerBitfield returns an empty bitfield for a committee of n members.
+func NewSignerBitfield(n int) *SignerBitfield {
+	return &SignerBitfield{
+		n:    n,
+		bits: make([]byte, (n+7)/8),
+	}
+}
+
+// Len returns the size of the committee.
+func (b *SignerBitfield) Len() int {
+	return b.n
+}
+
+// Set marks the member at index i as a signer.
+func (b *SignerBitfield) Set(i int) error {
+	if i < 0 || i >= b.n {
+		return errors.New("bls: bitfield index out of range")
+	}
+	b.bits[i/8] |= byte(1) << uint(i&7)
+	return nil
+}
+
+// Get returns true if the member at index i is a signer. It returns false
+// for an index out of range.
+func (b *SignerBitfield) Get(i int) bool {
+	if i < 0 || i >= b.n {
+		return false
+	}
+	return b.bits[i/8]&(byte(1)<<uint(i&7)) != 0
+}
+
+// Count returns the number of signers.
+func (b *SignerBitfield) Count() int {
+	count := 0
+	for i := 0; i < b.n; i++ {
+		if b.Get(i) {
+			count++
+		}
+	}
+	return count
+}
+
+// MarshalBinary returns a copy of the underlying bytes. The committee size is
+// not encoded and must be known by the receiver.
+func (b *SignerBitfield) MarshalBinary() ([]byte, error) {
+	buff := make([]byte, len(b.bits))
+	copy(buff, b.bits)
+	return buff, nil
+}
+
+// UnmarshalBinary sets the bitfield from buff. The length of buff must match
+// the committee size and the unused bits of the last byte must be zero.
+func (b *SignerBitfield) UnmarshalBinary(buff []byte) error {
+	if len(buff) != (b.n+7)/8 {
+		return errors.New("bls: bitfield of invalid length")
+	}
+	if rem := uint(b.n & 7); rem != 0 && buff[len(buff)-1]>>rem != 0 {
+		return errors.New("bls: bitfield has bits set out of range")
+	}
+	b.bits = make([]byte, len(buff))
+	copy(b.bits, buff)
+	return nil
+}
+
+// AggregatePublicKeysBitfield returns the sum of the public keys of the
+// members set in the bitfield. The list of public keys must be the whole
+// committee, in the order used to build the bitfield.
+func AggregatePublicKeysBitfield(suite pairing.Suite, publics []kyber.Point, signers *SignerBitfield) (kyber.Point, error) {
+	if len(publics) != signers.Len() {
+		return nil, errors.New("bls: bitfield length does not match the number of public keys")
+	}
+	aggregated := suite.G2().Point().Null()
+	for i, X := range publics {
+		if signers.Get(i) {
+			aggregated.Add(aggregated, X)
+		}
+	}
+	return aggregated, nil
+}
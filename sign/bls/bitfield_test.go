@@ -0,0 +1,57 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing/bn256"
+	"go.dedis.ch/kyber/v3/sign"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestSignerBitfield(t *testing.T) {
+	n := 1000
+	suite := bn256.NewSuite()
+	publics := make([]kyber.Point, n)
+	for i := range publics {
+		_, publics[i] = NewKeyPair(suite, random.New())
+	}
+
+	bf := NewSignerBitfield(n)
+	var signers []kyber.Point
+	for i := 0; i < n; i += 3 {
+		require.NoError(t, bf.Set(i))
+		signers = append(signers, publics[i])
+	}
+	require.Error(t, bf.Set(n))
+	require.Error(t, bf.Set(-1))
+	require.Equal(t, len(signers), bf.Count())
+	for i := 0; i < n; i++ {
+		require.Equal(t, i%3 == 0, bf.Get(i))
+	}
+
+	agg, err := AggregatePublicKeysBitfield(suite, publics, bf)
+	require.NoError(t, err)
+	require.True(t, agg.Equal(AggregatePublicKeys(suite, signers...)))
+
+	_, err = AggregatePublicKeysBitfield(suite, publics[1:], bf)
+	require.Error(t, err)
+
+	// same layout as sign.Mask
+	mask, err := sign.NewMask(suite, publics, nil)
+	require.NoError(t, err)
+	buff, err := bf.MarshalBinary()
+	require.NoError(t, err)
+	require.NoError(t, mask.SetMask(buff))
+	require.Equal(t, signers, mask.Participants())
+
+	bf2 := NewSignerBitfield(n)
+	require.NoError(t, bf2.UnmarshalBinary(buff))
+	require.Equal(t, bf, bf2)
+
+	require.Error(t, bf2.UnmarshalBinary(buff[1:]))
+	// bits beyond the committee size
+	require.Error(t, NewSignerBitfield(10).UnmarshalBinary([]byte{0xff, 0x04}))
+	require.NoError(t, NewSignerBitfield(10).UnmarshalBinary([]byte{0xff, 0x03}))
+}
@@ -12,10 +12,13 @@
 package bls
 
 import (
+	"bytes"
 	"crypto/cipher"
 	"crypto/sha256"
 	"errors"
 	"fmt"
+	"math/big"
+	"sort"
 
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/pairing"
@@ -36,21 +39,38 @@ func NewKeyPair(suite pairing.Suite, random cipher.Stream) (kyber.Scalar, kyber.
 // Sign creates a BLS signature S = x * H(m) on a message m using the private
 // key x. The signature S is a point on curve G1.
 func Sign(suite pairing.Suite, x kyber.Scalar, msg []byte) ([]byte, error) {
+	pre, err := PrecomputeMessage(suite, msg)
+	if err != nil {
+		return nil, err
+	}
+	return SignPrecomputed(suite, x, pre)
+}
+
+// Precomputed holds the hash H(m) of a message on curve G1, so that it is
+// computed once when the same message is signed with many keys or many
+// signatures on it are verified.
+type Precomputed struct {
+	hm kyber.Point
+}
+
+// PrecomputeMessage hashes the message m to the point H(m) used by Sign and
+// Verify.
+func PrecomputeMessage(suite pairing.Suite, msg []byte) (*Precomputed, error) {
 	hashable, ok := suite.G1().Point().(hashablePoint)
 	if !ok {
-		return nil, errors.New("point needs to implement hashablePoint")
+		return nil, errors.New("bls: point needs to implement hashablePoint")
 	}
-	HM := hashable.Hash(msg)
-	xHM := HM.Mul(x, HM)
+	return &Precomputed{hm: hashable.Hash(msg)}, nil
+}
 
-	s, err := xHM.MarshalBinary()
-	if err != nil {
-		return nil, err
-	}
-	return s, nil
+// SignPrecomputed is like Sign for the message hashed in pre.
+func SignPrecomputed(suite pairing.Suite, x kyber.Scalar, pre *Precomputed) ([]byte, error) {
+	return suite.G1().Point().Mul(x, pre.hm).MarshalBinary()
 }
 
-// AggregateSignatures combines signatures created using the Sign function
+// AggregateSignatures combines signatures created using the Sign function.
+// The aggregate of no signatures is the identity of G1, which verifies
+// against the aggregate of no keys.
 func AggregateSignatures(suite pairing.Suite, sigs ...[]byte) ([]byte, error) {
 	sig := suite.G1().Point()
 	for _, sigBytes := range sigs {
@@ -65,6 +85,8 @@ func AggregateSignatures(suite pairing.Suite, sigs ...[]byte) ([]byte, error) {
 
 // AggregatePublicKeys takes a slice of public G2 points and returns
 // the sum of those points. This is used to verify multisignatures.
+// The sum does not depend on the order of Xs, but parties that exchange or
+// hash the list of keys should agree on it by passing it through SortKeys.
 func AggregatePublicKeys(suite pairing.Suite, Xs ...kyber.Point) kyber.Point {
 	aggregated := suite.G2().Point()
 	for _, X := range Xs {
@@ -73,6 +95,29 @@ func AggregatePublicKeys(suite pairing.Suite, Xs ...kyber.Point) kyber.Point {
 	return aggregated
 }
 
+// SortKeys returns a copy of pubs sorted by the lexicographic order of the
+// keys' binary encoding, giving every party the same canonical list for a
+// given set of keys. The input slice is not modified.
+func SortKeys(pubs []kyber.Point) []kyber.Point {
+	type keyBytes struct {
+		pub kyber.Point
+		buf []byte
+	}
+	keys := make([]keyBytes, len(pubs))
+	for i, pub := range pubs {
+		buf, _ := pub.MarshalBinary()
+		keys[i] = keyBytes{pub, buf}
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i].buf, keys[j].buf) < 0
+	})
+	sorted := make([]kyber.Point, len(keys))
+	for i, k := range keys {
+		sorted[i] = k.pub
+	}
+	return sorted
+}
+
 // BatchVerify verifies a large number of publicKey/msg pairings with a single aggregated signature.
 // Since aggregation is generally much faster than verification, this can be a speed enhancement.
 // Benchmarks show a roughly 50% performance increase over individual signature verification
@@ -88,21 +133,18 @@ func BatchVerify(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig
 	if err := s.UnmarshalBinary(sig); err != nil {
 		return err
 	}
+	if !inSubgroup(suite.G1(), s) {
+		return errors.New("bls: signature not in the prime order subgroup")
+	}
 
-	var aggregatedLeft kyber.Point
+	aggregatedLeft := suite.GT().Point().Null()
 	for i := range msgs {
 		hashable, ok := suite.G1().Point().(hashablePoint)
 		if !ok {
 			return errors.New("bls: point needs to implement hashablePoint")
 		}
 		hm := hashable.Hash(msgs[i])
-		pair := suite.Pair(hm, publics[i])
-
-		if i == 0 {
-			aggregatedLeft = pair
-		} else {
-			aggregatedLeft.Add(aggregatedLeft, pair)
-		}
+		aggregatedLeft.Add(aggregatedLeft, suite.Pair(hm, publics[i]))
 	}
 
 	right := suite.Pair(s, suite.G2().Point().Base())
@@ -115,18 +157,27 @@ func BatchVerify(suite pairing.Suite, publics []kyber.Point, msgs [][]byte, sig
 // Verify checks the given BLS signature S on the message m using the public
 // key X by verifying that the equality e(H(m), X) == e(H(m), x*B2) ==
 // e(x*H(m), B2) == e(S, B2) holds where e is the pairing operation and B2 is
-// the base point from curve G2.
+// the base point from curve G2. The signature is rejected if it is not in
+// the prime order subgroup G1. The public key is trusted to be in G2, as
+// are the points read with UnmarshalBinary, which checks it.
 func Verify(suite pairing.Suite, X kyber.Point, msg, sig []byte) error {
-	hashable, ok := suite.G1().Point().(hashablePoint)
-	if !ok {
-		return errors.New("bls: point needs to implement hashablePoint")
+	pre, err := PrecomputeMessage(suite, msg)
+	if err != nil {
+		return err
 	}
-	HM := hashable.Hash(msg)
-	left := suite.Pair(HM, X)
+	return VerifyPrecomputed(suite, X, pre, sig)
+}
+
+// VerifyPrecomputed is like Verify for the message hashed in pre.
+func VerifyPrecomputed(suite pairing.Suite, X kyber.Point, pre *Precomputed, sig []byte) error {
 	s := suite.G1().Point()
 	if err := s.UnmarshalBinary(sig); err != nil {
 		return err
 	}
+	if !inSubgroup(suite.G1(), s) {
+		return errors.New("bls: signature not in the prime order subgroup")
+	}
+	left := suite.Pair(pre.hm, X)
 	right := suite.Pair(s, suite.G2().Point().Base())
 	if !left.Equal(right) {
 		return errors.New("bls: invalid signature")
@@ -134,6 +185,20 @@ func Verify(suite pairing.Suite, X kyber.Point, msg, sig []byte) error {
 	return nil
 }
 
+// inSubgroup returns true if P is in the subgroup of prime order of g, by
+// checking that (n-1)*P + P is the identity, where n is the order. It is
+// needed for groups whose UnmarshalBinary only checks that a point is on a
+// curve with a cofactor other than one. Groups which do not implement
+// kyber.GroupParameters are trusted to have a prime order.
+func inSubgroup(g kyber.Group, P kyber.Point) bool {
+	params, ok := g.(kyber.GroupParameters)
+	if !ok || params.Cofactor().Cmp(big.NewInt(1)) == 0 {
+		return true
+	}
+	Q := g.Point().Mul(g.Scalar().SetInt64(-1), P)
+	return Q.Add(Q, P).Equal(g.Point().Null())
+}
+
 func distinct(msgs [][]byte) bool {
 	m := make(map[[32]byte]bool)
 	for _, msg := range msgs {
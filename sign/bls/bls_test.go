@@ -2,6 +2,7 @@ package bls
 
 import (
 	"crypto/rand"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -62,6 +63,63 @@ func TestBLSAggregateSignatures(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestBLSAggregateEdgeCases(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+
+	// the empty aggregate is the identity and verifies against no keys
+	empty, err := AggregateSignatures(suite)
+	require.NoError(t, err)
+	identity, err := suite.G1().Point().Null().MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, identity, empty)
+	require.NoError(t, BatchVerify(suite, nil, nil, empty))
+	require.NoError(t, Verify(suite, AggregatePublicKeys(suite), msg, empty))
+
+	// a non-empty set of keys does not accept it
+	private, public := NewKeyPair(suite, random.New())
+	require.Error(t, Verify(suite, public, msg, empty))
+	require.Error(t, BatchVerify(suite, []kyber.Point{public}, [][]byte{msg}, empty))
+
+	// the aggregate of a single signature is that signature
+	sig, err := Sign(suite, private, msg)
+	require.NoError(t, err)
+	single, err := AggregateSignatures(suite, sig)
+	require.NoError(t, err)
+	require.Equal(t, sig, single)
+	require.NoError(t, Verify(suite, AggregatePublicKeys(suite, public), msg, single))
+	require.NoError(t, BatchVerify(suite, []kyber.Point{public}, [][]byte{msg}, single))
+}
+
+func TestBLSSortKeys(t *testing.T) {
+	suite := bn256.NewSuite()
+	pubs := make([]kyber.Point, 6)
+	for i := range pubs {
+		_, pubs[i] = NewKeyPair(suite, random.New())
+	}
+	reversed := make([]kyber.Point, len(pubs))
+	for i := range pubs {
+		reversed[len(pubs)-1-i] = pubs[i]
+	}
+	first := pubs[0]
+
+	sorted := SortKeys(pubs)
+	require.Equal(t, first, pubs[0])
+	sortedRev := SortKeys(reversed)
+	require.Len(t, sorted, len(pubs))
+	for i := range sorted {
+		require.True(t, sorted[i].Equal(sortedRev[i]))
+		if i > 0 {
+			prev, _ := sorted[i-1].MarshalBinary()
+			cur, _ := sorted[i].MarshalBinary()
+			require.True(t, string(prev) < string(cur))
+		}
+	}
+
+	require.True(t, AggregatePublicKeys(suite, sorted...).Equal(AggregatePublicKeys(suite, sortedRev...)))
+	require.True(t, AggregatePublicKeys(suite, sorted...).Equal(AggregatePublicKeys(suite, pubs...)))
+}
+
 func TestBLSFailAggregatedSig(t *testing.T) {
 	msg := []byte("Hello Boneh-Lynn-Shacham")
 	suite := bn256.NewSuite()
@@ -148,6 +206,27 @@ func TestBLSFailBatchVerify(t *testing.T) {
 
 }
 
+func TestBLSPrecomputed(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	pre, err := PrecomputeMessage(suite, msg)
+	require.NoError(t, err)
+	for i := 0; i < 3; i++ {
+		private, public := NewKeyPair(suite, random.New())
+		sig, err := SignPrecomputed(suite, private, pre)
+		require.NoError(t, err)
+		sig2, err := Sign(suite, private, msg)
+		require.NoError(t, err)
+		require.Equal(t, sig2, sig)
+		require.NoError(t, VerifyPrecomputed(suite, public, pre, sig))
+		require.NoError(t, Verify(suite, public, msg, sig))
+
+		other, err := PrecomputeMessage(suite, []byte("other"))
+		require.NoError(t, err)
+		require.Error(t, VerifyPrecomputed(suite, public, other, sig))
+	}
+}
+
 func BenchmarkBLSKeyCreation(b *testing.B) {
 	suite := bn256.NewSuite()
 	b.ResetTimer()
@@ -226,6 +305,39 @@ func BenchmarkBLSVerifyBatchVerify(b *testing.B) {
 	}
 }
 
+func benchmarkBLSVerifyOneMessage(b *testing.B, precompute bool) {
+	suite := bn256.NewSuite()
+	msg := []byte("Hello many times Boneh-Lynn-Shacham")
+	publics := make([]kyber.Point, 100)
+	sigs := make([][]byte, 100)
+	for i := range publics {
+		var private kyber.Scalar
+		private, publics[i] = NewKeyPair(suite, random.New())
+		sigs[i], _ = Sign(suite, private, msg)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if precompute {
+			pre, _ := PrecomputeMessage(suite, msg)
+			for j, public := range publics {
+				_ = VerifyPrecomputed(suite, public, pre, sigs[j])
+			}
+		} else {
+			for j, public := range publics {
+				_ = Verify(suite, public, msg, sigs[j])
+			}
+		}
+	}
+}
+
+func BenchmarkBLSVerify100(b *testing.B) {
+	benchmarkBLSVerifyOneMessage(b, false)
+}
+
+func BenchmarkBLSVerifyPrecomputed100(b *testing.B) {
+	benchmarkBLSVerifyOneMessage(b, true)
+}
+
 func TestBinaryMarshalAfterAggregation_issue400(t *testing.T) {
 	suite := bn256.NewSuite()
 
@@ -251,3 +363,37 @@ func TestBinaryMarshalAfterAggregation_issue400(t *testing.T) {
 	err = point.UnmarshalBinary(bits)
 	require.Nil(t, err)
 }
+
+// twistPointOutsideG2 is the encoding of a point of the curve of bn256.G2
+// which is not in G2, the one built by the tests of pairing/bn256 from the
+// hash to the twist before the cofactor is cleared.
+const twistPointOutsideG2 = "541c7d9f2e9ad75235ed0dc87c51afe0828d89021e745fd3ad0586ef24f39a6b" +
+	"31207a2065f46a5b948fce6fe5c13e85abaf5631e2f894b47dcd4fce14f6c57b" +
+	"15a8f52014bcd9b9b17a27d775eaf3700da8db2b9fb4d2618bf888b4d0e25b35" +
+	"7e1ff1b4c0d21f3bcf82af32bb8bdf9cc478fffe4a426be99a3b4652b81340dc"
+
+func TestBLSSubgroupCheck(t *testing.T) {
+	msg := []byte("Hello Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	private, public := NewKeyPair(suite, random.New())
+	sig, err := Sign(suite, private, msg)
+	require.NoError(t, err)
+
+	// the point is on the curve, so it is rejected by UnmarshalBinary but
+	// accepted by UnmarshalBinaryUnchecked, and it is not in G2
+	buf, err := hex.DecodeString(twistPointOutsideG2)
+	require.NoError(t, err)
+	outside := suite.G2().Point()
+	require.Error(t, outside.UnmarshalBinary(buf))
+	unchecked := outside.(interface{ UnmarshalBinaryUnchecked([]byte) error })
+	require.NoError(t, unchecked.UnmarshalBinaryUnchecked(buf))
+	require.False(t, inSubgroup(suite.G2(), outside))
+	require.True(t, inSubgroup(suite.G2(), public))
+
+	// a rogue public key cannot be read from the network
+	rogue := suite.G2().Point().Add(public, outside)
+	buf, err = rogue.MarshalBinary()
+	require.NoError(t, err)
+	require.Error(t, suite.G2().Point().UnmarshalBinary(buf))
+	require.NoError(t, Verify(suite, public, msg, sig))
+}
@@ -0,0 +1,217 @@
+package eddsa
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"errors"
+	"fmt"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/ed448"
+	"go.dedis.ch/kyber/v3/util/random"
+	"golang.org/x/crypto/sha3"
+)
+
+// Sizes in bytes of Ed448 keys and signatures.
+const (
+	Ed448SeedSize      = 57
+	Ed448PublicKeySize = 57
+	Ed448SignatureSize = 114
+)
+
+var group448 = ed448.NewCurve()
+
+// Ed448 is a structure holding the data necessary to make a series of Ed448
+// signatures, as specified in RFC 8032.
+//
+// WARNING: the arithmetic of group/ed448 runs in variable time, so deriving
+// the key and signing leak the secret scalar and the nonce through timing.
+// Ed448 is not constant time in the sense of suites.RequireConstantTime,
+// and must not be used where an attacker can measure the time of signing.
+type Ed448 struct {
+	// Secret being already hashed + bit tweaked
+	Secret kyber.Scalar
+	// Public is the corresponding public key
+	Public kyber.Point
+
+	seed   []byte
+	prefix []byte
+}
+
+// NewEd448 will return a freshly generated key pair to use for generating
+// Ed448 signatures. WARNING: the key is derived in variable time, see Ed448.
+func NewEd448(stream cipher.Stream) *Ed448 {
+	if stream == nil {
+		panic("stream is required")
+	}
+	seed := make([]byte, Ed448SeedSize)
+	random.Bytes(seed, stream)
+	return newEd448(seed)
+}
+
+func newEd448(seed []byte) *Ed448 {
+	h := shake256(seed)
+	h[0] &= 0xfc
+	h[55] |= 0x80
+	h[56] = 0
+
+	secret := group448.Scalar().SetBytes(h[:57])
+	return &Ed448{
+		Secret: secret,
+		Public: group448.Point().Mul(secret, nil),
+		seed:   seed,
+		prefix: h[57:],
+	}
+}
+
+// MarshalBinary returns "seed || Public", the same layout as the one of
+// EdDSA.MarshalBinary.
+func (e *Ed448) MarshalBinary() ([]byte, error) {
+	pBuff, err := e.Public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, e.seed...), pBuff...), nil
+}
+
+// UnmarshalBinary reads a key pair encoded by MarshalBinary.
+func (e *Ed448) UnmarshalBinary(buff []byte) error {
+	if len(buff) != Ed448SeedSize+Ed448PublicKeySize {
+		return errors.New("wrong length for decoding Ed448 private")
+	}
+	*e = *newEd448(append([]byte{}, buff[:Ed448SeedSize]...))
+	return nil
+}
+
+// Sign will return an Ed448 signature of the message msg, with an empty
+// context.
+func (e *Ed448) Sign(msg []byte) ([]byte, error) {
+	return e.SignWithContext(msg, nil)
+}
+
+// SignWithContext will return an Ed448 signature of the message msg for the
+// given context, which is at most 255 bytes long.
+func (e *Ed448) SignWithContext(msg, context []byte) ([]byte, error) {
+	dom, err := dom4(context)
+	if err != nil {
+		return nil, err
+	}
+
+	// deterministic random secret and its commit
+	r := group448.Scalar().SetBytes(shake256(dom, e.prefix, msg))
+	R := group448.Point().Mul(r, nil)
+
+	Rbuff, err := R.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	Abuff, err := e.Public.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// challenge H(dom4 || R || Public || Msg) and response s = r + h * secret
+	h := group448.Scalar().SetBytes(shake256(dom, Rbuff, Abuff, msg))
+	s := group448.Scalar().Mul(e.Secret, h)
+	s.Add(r, s)
+
+	sBuff, err := s.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+
+	// return R || s, where s is padded to 57 bytes
+	sig := make([]byte, Ed448SignatureSize)
+	copy(sig, Rbuff)
+	copy(sig[Ed448PublicKeySize:], sBuff)
+	return sig, nil
+}
+
+// VerifyEd448 returns nil if sig is a valid Ed448 signature of msg with an
+// empty context under the encoded public key pub, or an error otherwise.
+func VerifyEd448(pub, msg, sig []byte) error {
+	return VerifyEd448WithContext(pub, msg, nil, sig)
+}
+
+// VerifyEd448WithContext returns nil if sig is a valid Ed448 signature of msg
+// for the given context under the encoded public key pub, or an error
+// otherwise. It rejects non-canonical encodings of the points and of s, and
+// checks the cofactored verification equation [4][s]B = [4]R + [4][h]A.
+func VerifyEd448WithContext(pub, msg, context, sig []byte) error {
+	if len(sig) != Ed448SignatureSize {
+		return fmt.Errorf("signature length invalid, expect %d but got %v", Ed448SignatureSize, len(sig))
+	}
+	dom, err := dom4(context)
+	if err != nil {
+		return err
+	}
+
+	R, err := decodeEd448Point(sig[:Ed448PublicKeySize])
+	if err != nil {
+		return fmt.Errorf("got R invalid point: %s", err)
+	}
+	public, err := decodeEd448Point(pub)
+	if err != nil {
+		return fmt.Errorf("invalid public key: %s", err)
+	}
+
+	sBuff := sig[Ed448PublicKeySize:]
+	s := group448.Scalar().SetBytes(sBuff)
+	canonical, _ := s.MarshalBinary()
+	if sBuff[len(sBuff)-1] != 0 || !bytes.Equal(canonical, sBuff[:len(sBuff)-1]) {
+		return errors.New("signature is not canonical")
+	}
+
+	h := group448.Scalar().SetBytes(shake256(dom, sig[:Ed448PublicKeySize], pub, msg))
+	S := group448.Point().Mul(s, nil)
+	RhA := group448.Point().Mul(h, public)
+	RhA.Add(R, RhA)
+
+	diff := S.Sub(S, RhA)
+	diff.Mul(group448.Scalar().SetInt64(4), diff)
+	if !diff.Equal(group448.Point().Null()) {
+		return errors.New("reconstructed S is not equal to signature")
+	}
+	return nil
+}
+
+// decodeEd448Point decodes a point and checks that its encoding is the
+// canonical one, i.e. that the y-coordinate is reduced and that the sign bit
+// is not set for a zero x-coordinate.
+func decodeEd448Point(b []byte) (kyber.Point, error) {
+	if len(b) != Ed448PublicKeySize {
+		return nil, errors.New("wrong length")
+	}
+	P := group448.Point()
+	if err := P.UnmarshalBinary(b); err != nil {
+		return nil, err
+	}
+	canonical, err := P.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(canonical, b) {
+		return nil, errors.New("point is not canonical")
+	}
+	return P, nil
+}
+
+// dom4 returns the prefix of every hash of Ed448 with the given context.
+func dom4(context []byte) ([]byte, error) {
+	if len(context) > 255 {
+		return nil, errors.New("context longer than 255 bytes")
+	}
+	dom := append([]byte("SigEd448"), 0, byte(len(context)))
+	return append(dom, context...), nil
+}
+
+// shake256 returns 114 bytes of SHAKE256 output of the concatenated inputs.
+func shake256(inputs ...[]byte) []byte {
+	h := sha3.NewShake256()
+	for _, in := range inputs {
+		_, _ = h.Write(in)
+	}
+	out := make([]byte, 114)
+	_, _ = h.Read(out)
+	return out
+}
@@ -0,0 +1,76 @@
+// Package scheme provides a Signer that picks the signature scheme suited to
+// a cipher suite, so that applications supporting several suites can sign
+// and verify through a single type.
+//
+// The selection rules are:
+//
+//   - a suite implementing pairing.Suite, such as pairing.SuiteBn256, uses
+//     BLS signatures (package sign/bls): public keys are points of G2 and
+//     signatures points of G1;
+//   - any other suite uses Schnorr signatures (package sign/schnorr) in the
+//     group of the suite.
+package scheme
+
+import (
+	"crypto/cipher"
+
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
+	"go.dedis.ch/kyber/v3/sign"
+	"go.dedis.ch/kyber/v3/sign/bls"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+	"go.dedis.ch/kyber/v3/suites"
+)
+
+var _ sign.Scheme = (*Signer)(nil)
+
+// Signer signs and verifies messages with the scheme selected for its suite.
+// It implements sign.Scheme.
+type Signer struct {
+	suite   suites.Suite
+	pairing pairing.Suite
+}
+
+// New returns a Signer for the given suite, following the selection rules
+// of the package.
+func New(suite suites.Suite) *Signer {
+	s := &Signer{suite: suite}
+	if p, ok := suite.(pairing.Suite); ok {
+		s.pairing = p
+	}
+	return s
+}
+
+// Name returns the name of the selected scheme, "bls" or "schnorr".
+func (s *Signer) Name() string {
+	if s.pairing != nil {
+		return "bls"
+	}
+	return "schnorr"
+}
+
+// NewKeyPair returns a new key pair for the selected scheme.
+func (s *Signer) NewKeyPair(random cipher.Stream) (kyber.Scalar, kyber.Point) {
+	if s.pairing != nil {
+		return bls.NewKeyPair(s.pairing, random)
+	}
+	private := s.suite.Scalar().Pick(random)
+	return private, s.suite.Point().Mul(private, nil)
+}
+
+// Sign returns a signature of msg with the private key.
+func (s *Signer) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	if s.pairing != nil {
+		return bls.Sign(s.pairing, private, msg)
+	}
+	return schnorr.Sign(s.suite, private, msg)
+}
+
+// Verify returns nil if sig is a valid signature of msg under the public
+// key, or an error otherwise.
+func (s *Signer) Verify(public kyber.Point, msg, sig []byte) error {
+	if s.pairing != nil {
+		return bls.Verify(s.pairing, public, msg, sig)
+	}
+	return schnorr.Verify(s.suite, public, msg, sig)
+}
@@ -0,0 +1,34 @@
+package scheme
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/pairing"
+	"go.dedis.ch/kyber/v3/suites"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+func TestSigner(t *testing.T) {
+	msg := []byte("Hello facade")
+	for _, tc := range []struct {
+		suite suites.Suite
+		name  string
+	}{
+		{edwards25519.NewBlakeSHA256Ed25519(), "schnorr"},
+		{pairing.NewSuiteBn256(), "bls"},
+	} {
+		s := New(tc.suite)
+		require.Equal(t, tc.name, s.Name())
+
+		private, public := s.NewKeyPair(random.New())
+		sig, err := s.Sign(private, msg)
+		require.NoError(t, err)
+		require.NoError(t, s.Verify(public, msg, sig), tc.name)
+
+		require.Error(t, s.Verify(public, []byte("other"), sig), tc.name)
+		_, other := s.NewKeyPair(random.New())
+		require.Error(t, s.Verify(other, msg, sig), tc.name)
+	}
+}
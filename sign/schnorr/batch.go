@@ -0,0 +1,183 @@
+package schnorr
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sort"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// BatchVerify verifies n signatures at once, sigs[i] being the signature of
+// msgs[i] under pubs[i], with the cofactored equation of VerifyCofactored,
+// not the one of Verify. It returns true and no index if VerifyCofactored
+// accepts all of them. Otherwise it returns false and the indices, in
+// increasing order, of the signatures that VerifyCofactored rejects, so the
+// result for a signature does not depend on the others of the batch.
+//
+// Each signature is first decoded and checked as in Verify. The equations
+// s_i*G = R_i + h_i*A_i of the remaining signatures are then combined with
+// random scalars z_i into the single equation
+//
+//	(sum z_i*s_i)*G = sum z_i*R_i + sum (z_i*h_i)*A_i
+//
+// multiplied by the cofactor, which is computed with one multi-scalar
+// multiplication, faster than n separate verifications. Only if it fails are
+// the signatures checked one by one with VerifyCofactored to find the
+// invalid ones.
+func BatchVerify(suite Suite, pubs []kyber.Point, msgs [][]byte, sigs [][]byte) (bool, []int) {
+	if len(pubs) != len(msgs) || len(pubs) != len(sigs) {
+		failed := make([]int, len(pubs))
+		for i := range failed {
+			failed[i] = i
+		}
+		return false, failed
+	}
+
+	var failed, batched []int
+	stream := suite.RandomStream()
+	sum := suite.Scalar().Zero()
+	scalars := make([]kyber.Scalar, 0, 2*len(pubs)+1)
+	points := make([]kyber.Point, 0, 2*len(pubs)+1)
+	for i := range pubs {
+		pub, err := pubs[i].MarshalBinary()
+		if err != nil {
+			failed = append(failed, i)
+			continue
+		}
+		R, public, s, h, err := decode(suite, pub, msgs[i], sigs[i])
+		if err != nil {
+			failed = append(failed, i)
+			continue
+		}
+		z := suite.Scalar().Pick(stream)
+		sum.Add(sum, suite.Scalar().Mul(z, s))
+		scalars = append(scalars, z, suite.Scalar().Mul(z, h))
+		points = append(points, R, public)
+		batched = append(batched, i)
+	}
+	if len(batched) == 0 {
+		return len(failed) == 0, failed
+	}
+	scalars = append(scalars, sum.Neg(sum))
+	points = append(points, suite.Point().Base())
+
+	if clearCofactor(suite, multiMul(suite, scalars, points)).Equal(suite.Point().Null()) {
+		return len(failed) == 0, failed
+	}
+
+	// find the invalid signatures
+	for _, i := range batched {
+		if VerifyCofactored(suite, pubs[i], msgs[i], sigs[i]) != nil {
+			failed = append(failed, i)
+		}
+	}
+	sort.Ints(failed)
+	return false, failed
+}
+
+// VerifyCofactored is like Verify, but if the group implements
+// kyber.GroupParameters with a cofactor other than one, as edwards25519, it
+// checks the equation s*G = R + h*A multiplied by the cofactor. It then
+// accepts the signatures that Verify accepts, and also the ones crafted with
+// a component of small order in R or in the public key, which Verify
+// rejects. It accepts exactly the signatures that BatchVerify accepts, so
+// that one can replace the other without changing which signatures are
+// valid. Signatures made with Sign are accepted by both Verify and
+// VerifyCofactored.
+func VerifyCofactored(g kyber.Group, public kyber.Point, msg, sig []byte) error {
+	pub, err := public.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("error unmarshalling public key: %s", err)
+	}
+	R, A, s, h, err := decode(g, pub, msg, sig)
+	if err != nil {
+		return err
+	}
+	// s*G - R - h*A
+	d := g.Point().Mul(s, nil)
+	d.Sub(d, R)
+	d.Sub(d, g.Point().Mul(h, A))
+	if !clearCofactor(g, d).Equal(g.Point().Null()) {
+		return errors.New("schnorr: invalid signature")
+	}
+	return nil
+}
+
+// clearCofactor multiplies p by the cofactor of g, if g implements
+// kyber.GroupParameters, and returns it.
+func clearCofactor(g kyber.Group, p kyber.Point) kyber.Point {
+	if params, ok := g.(kyber.GroupParameters); ok && params.Cofactor().Cmp(big.NewInt(1)) != 0 {
+		p.Mul(g.Scalar().SetInt64(params.Cofactor().Int64()), p)
+	}
+	return p
+}
+
+// multiMul returns the sum of scalars[i]*points[i] with the bucket method
+// of Pippenger, using only the additions of the group: for every window of
+// c bits of the scalars, from the most significant one, the points are
+// added to the bucket of their digit and the buckets are summed with their
+// weight in 2^c additions.
+func multiMul(g kyber.Group, scalars []kyber.Scalar, points []kyber.Point) kyber.Point {
+	// MarshalBinary is big endian in some groups, as the ones of nist
+	one, _ := g.Scalar().One().MarshalBinary()
+	bigEndian := len(one) > 1 && one[len(one)-1] == 1
+	digits := make([][]byte, len(scalars))
+	for i, s := range scalars {
+		digits[i], _ = s.MarshalBinary()
+		if bigEndian {
+			reverse(digits[i])
+		}
+	}
+	nbits := 8 * len(digits[0])
+
+	c := bits.Len(uint(len(points))) - 2
+	if c < 2 {
+		c = 2
+	}
+	if c > 12 {
+		c = 12
+	}
+	buckets := make([]kyber.Point, 1<<uint(c))
+	acc := g.Point().Null()
+	for w := (nbits - 1) / c * c; w >= 0; w -= c {
+		for j := 0; j < c; j++ {
+			acc.Add(acc, acc)
+		}
+		for j := range buckets {
+			buckets[j] = g.Point().Null()
+		}
+		for i, d := range digits {
+			if b := window(d, w, c); b != 0 {
+				buckets[b].Add(buckets[b], points[i])
+			}
+		}
+		// sum of j*buckets[j] as a running sum of the buckets from the top
+		running := g.Point().Null()
+		for j := len(buckets) - 1; j > 0; j-- {
+			running.Add(running, buckets[j])
+			acc.Add(acc, running)
+		}
+	}
+	return acc
+}
+
+func reverse(b []byte) {
+	for i, j := 0, len(b)-1; i < j; i, j = i+1, j-1 {
+		b[i], b[j] = b[j], b[i]
+	}
+}
+
+// window returns the c bits of the little-endian d starting at bit w.
+func window(d []byte, w, c int) int {
+	var v int
+	for j := c - 1; j >= 0; j-- {
+		v <<= 1
+		if b := w + j; b/8 < len(d) {
+			v |= int(d[b/8]>>uint(b%8)) & 1
+		}
+	}
+	return v
+}
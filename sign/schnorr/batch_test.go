@@ -0,0 +1,131 @@
+package schnorr
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/group/nist"
+)
+
+func batchSignatures(t testing.TB, suite Suite, n int) ([]kyber.Point, [][]byte, [][]byte) {
+	pubs := make([]kyber.Point, n)
+	msgs := make([][]byte, n)
+	sigs := make([][]byte, n)
+	for i := range pubs {
+		priv := suite.Scalar().Pick(suite.RandomStream())
+		pubs[i] = suite.Point().Mul(priv, nil)
+		msgs[i] = []byte(fmt.Sprintf("message %d", i))
+		var err error
+		sigs[i], err = Sign(suite, priv, msgs[i])
+		require.NoError(t, err)
+	}
+	return pubs, msgs, sigs
+}
+
+func TestBatchVerify(t *testing.T) {
+	for _, suite := range []Suite{edwards25519.NewBlakeSHA256Ed25519(), nist.NewBlakeSHA256P256()} {
+		pubs, msgs, sigs := batchSignatures(t, suite, 20)
+		ok, failed := BatchVerify(suite, pubs, msgs, sigs)
+		require.True(t, ok, suite.String())
+		require.Empty(t, failed)
+
+		// a signature on another message
+		msgs[7] = []byte("another message")
+		ok, failed = BatchVerify(suite, pubs, msgs, sigs)
+		require.False(t, ok)
+		require.Equal(t, []int{7}, failed)
+
+		// and a malformed one
+		sigs[3] = sigs[3][1:]
+		ok, failed = BatchVerify(suite, pubs, msgs, sigs)
+		require.False(t, ok)
+		require.Equal(t, []int{3, 7}, failed)
+
+		ok, failed = BatchVerify(suite, pubs, msgs[1:], sigs)
+		require.False(t, ok)
+		require.Len(t, failed, len(pubs))
+	}
+
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	ok, failed := BatchVerify(suite, nil, nil, nil)
+	require.True(t, ok)
+	require.Empty(t, failed)
+}
+
+// signWithTorsion returns a signature of msg whose commitment R has a
+// component of small order, which Verify rejects.
+func signWithTorsion(t *testing.T, suite Suite, private kyber.Scalar, msg []byte, torsion kyber.Point) []byte {
+	k := suite.Scalar().Pick(suite.RandomStream())
+	R := suite.Point().Mul(k, nil)
+	R.Add(R, torsion)
+	h, err := hash(suite, suite.Point().Mul(private, nil), R, msg)
+	require.NoError(t, err)
+	s := suite.Scalar().Add(k, suite.Scalar().Mul(private, h))
+	buf, err := R.MarshalBinary()
+	require.NoError(t, err)
+	sBuf, err := s.MarshalBinary()
+	require.NoError(t, err)
+	return append(buf, sBuf...)
+}
+
+func TestBatchVerifyMixedOrder(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	order2, order8 := suite.Point(), suite.Point()
+	buf, _ := hex.DecodeString("ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f")
+	require.NoError(t, order2.UnmarshalBinary(buf))
+	buf, _ = hex.DecodeString("26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05")
+	require.NoError(t, order8.UnmarshalBinary(buf))
+
+	pubs, msgs, sigs := batchSignatures(t, suite, 8)
+	privs := make([]kyber.Scalar, 3)
+	for i := range privs {
+		privs[i] = suite.Scalar().Pick(suite.RandomStream())
+		pubs = append(pubs, suite.Point().Mul(privs[i], nil))
+		msgs = append(msgs, []byte("mixed order"))
+	}
+	// with two components of order 2, a cofactorless batch with random
+	// scalars would accept half of the time
+	for _, torsion := range []kyber.Point{order8, order2, order2} {
+		sigs = append(sigs, signWithTorsion(t, suite, privs[len(sigs)-8], msgs[len(sigs)], torsion))
+	}
+	for i := 8; i < len(sigs); i++ {
+		require.Error(t, Verify(suite, pubs[i], msgs[i], sigs[i]))
+		require.NoError(t, VerifyCofactored(suite, pubs[i], msgs[i], sigs[i]))
+	}
+	// the cofactored batch equation accepts them as VerifyCofactored does
+	for i := 0; i < 16; i++ {
+		ok, failed := BatchVerify(suite, pubs, msgs, sigs)
+		require.True(t, ok)
+		require.Empty(t, failed)
+	}
+	// whatever the other signatures of the batch
+	msgs[2] = []byte("another message")
+	require.Error(t, VerifyCofactored(suite, pubs[2], msgs[2], sigs[2]))
+	ok, failed := BatchVerify(suite, pubs, msgs, sigs)
+	require.False(t, ok)
+	require.Equal(t, []int{2}, failed)
+}
+
+func benchmarkVerify(b *testing.B, n int, batch bool) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	pubs, msgs, sigs := batchSignatures(b, suite, n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if batch {
+			BatchVerify(suite, pubs, msgs, sigs)
+		} else {
+			for j := range pubs {
+				_ = Verify(suite, pubs[j], msgs[j], sigs[j])
+			}
+		}
+	}
+}
+
+// On edwards25519, BenchmarkBatchVerify64 takes about two thirds of the time
+// of BenchmarkVerify64, which verifies the same signatures one at a time.
+func BenchmarkVerify64(b *testing.B)      { benchmarkVerify(b, 64, false) }
+func BenchmarkBatchVerify64(b *testing.B) { benchmarkVerify(b, 64, true) }
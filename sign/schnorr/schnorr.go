@@ -63,6 +63,29 @@ func Sign(s Suite, private kyber.Scalar, msg []byte) ([]byte, error) {
 // additional checks around the canonicality and ensures the public key
 // does not have a small order when using `edwards25519` group.
 func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
+	R, public, s, h, err := decode(g, pub, msg, sig)
+	if err != nil {
+		return err
+	}
+
+	// compute S = g^s
+	S := g.Point().Mul(s, nil)
+	// compute RAh = R + A^h
+	Ah := g.Point().Mul(h, public)
+	RAs := g.Point().Add(R, Ah)
+
+	if !S.Equal(RAs) {
+		return errors.New("schnorr: invalid signature")
+	}
+
+	return nil
+
+}
+
+// decode runs the checks of VerifyWithChecks on the encodings of the public
+// key and of the signature, and returns the commitment R, the public key,
+// the response s and the challenge h = hash(public || R || msg).
+func decode(g kyber.Group, pub, msg, sig []byte) (kyber.Point, kyber.Point, kyber.Scalar, kyber.Scalar, error) {
 	type scalarCanCheckCanonical interface {
 		IsCanonical(b []byte) bool
 	}
@@ -78,57 +101,45 @@ func VerifyWithChecks(g kyber.Group, pub, msg, sig []byte) error {
 	scalarSize := s.MarshalSize()
 	sigSize := scalarSize + pointSize
 	if len(sig) != sigSize {
-		return fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
+		return nil, nil, nil, nil, fmt.Errorf("schnorr: signature of invalid length %d instead of %d", len(sig), sigSize)
 	}
 	if err := R.UnmarshalBinary(sig[:pointSize]); err != nil {
-		return err
+		return nil, nil, nil, nil, err
 	}
 	if p, ok := R.(pointCanCheckCanonicalAndSmallOrder); ok {
 		if !p.IsCanonical(sig[:pointSize]) {
-			return fmt.Errorf("R is not canonical")
+			return nil, nil, nil, nil, fmt.Errorf("R is not canonical")
 		}
 		if p.HasSmallOrder() {
-			return fmt.Errorf("R has small order")
+			return nil, nil, nil, nil, fmt.Errorf("R has small order")
 		}
 	}
 	if s, ok := g.Scalar().(scalarCanCheckCanonical); ok && !s.IsCanonical(sig[pointSize:]) {
-		return fmt.Errorf("signature is not canonical")
+		return nil, nil, nil, nil, fmt.Errorf("signature is not canonical")
 	}
 	if err := s.UnmarshalBinary(sig[pointSize:]); err != nil {
-		return err
+		return nil, nil, nil, nil, err
 	}
 
 	public := g.Point()
 	err := public.UnmarshalBinary(pub)
 	if err != nil {
-		return fmt.Errorf("schnorr: error unmarshalling public key")
+		return nil, nil, nil, nil, fmt.Errorf("schnorr: error unmarshalling public key")
 	}
 	if p, ok := public.(pointCanCheckCanonicalAndSmallOrder); ok {
 		if !p.IsCanonical(pub) {
-			return fmt.Errorf("public key is not canonical")
+			return nil, nil, nil, nil, fmt.Errorf("public key is not canonical")
 		}
 		if p.HasSmallOrder() {
-			return fmt.Errorf("public key has small order")
+			return nil, nil, nil, nil, fmt.Errorf("public key has small order")
 		}
 	}
 	// recompute hash(public || R || msg)
 	h, err := hash(g, public, R, msg)
 	if err != nil {
-		return err
+		return nil, nil, nil, nil, err
 	}
-
-	// compute S = g^s
-	S := g.Point().Mul(s, nil)
-	// compute RAh = R + A^h
-	Ah := g.Point().Mul(h, public)
-	RAs := g.Point().Add(R, Ah)
-
-	if !S.Equal(RAs) {
-		return errors.New("schnorr: invalid signature")
-	}
-
-	return nil
-
+	return R, public, s, h, nil
 }
 
 // Verify verifies a given Schnorr signature. It returns nil iff the
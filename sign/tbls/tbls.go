@@ -7,12 +7,15 @@
 // can then be used to recover the full (regular) BLS signature S via Lagrange
 // interpolation. The signature S can be verified with the initially
 // established group key X. Signatures are points on curve G1 and public keys
-// are points on curve G2.
+// are points on curve G2. To place public keys on G1 and signatures on G2
+// instead, use the suite returned by pairing.Swap.
 package tbls
 
 import (
 	"bytes"
 	"encoding/binary"
+	"runtime"
+	"sync"
 
 	"go.dedis.ch/kyber/v3/pairing"
 	"go.dedis.ch/kyber/v3/share"
@@ -57,6 +60,25 @@ func Sign(suite pairing.Suite, private *share.PriShare, msg []byte) ([]byte, err
 	return buf.Bytes(), nil
 }
 
+// Signer produces threshold BLS signature shares with a fixed secret key
+// share, for example the one of a DKG result.
+type Signer struct {
+	suite   pairing.Suite
+	private *share.PriShare
+}
+
+// NewSigner returns a Signer using the given secret key share xi, whose
+// index i is written in every signature share it creates.
+func NewSigner(suite pairing.Suite, private *share.PriShare) *Signer {
+	return &Signer{suite: suite, private: private}
+}
+
+// Sign creates a threshold BLS signature share Si = i || xi * H(m) on the
+// message m, as Sign does.
+func (s *Signer) Sign(msg []byte) ([]byte, error) {
+	return Sign(s.suite, s.private, msg)
+}
+
 // Verify checks the given threshold BLS signature Si on the message m using
 // the public key share Xi that is associated to the secret key share xi. This
 // public key share Xi can be computed by evaluating the public sharing
@@ -70,11 +92,45 @@ func Verify(suite pairing.Suite, public *share.PubPoly, msg, sig []byte) error {
 	return bls.Verify(suite, public.Eval(i).V, msg, s.Value())
 }
 
+// VerifyPartials verifies the given signature shares on msg as Verify would,
+// but in parallel on at most GOMAXPROCS goroutines. The error for partials[i]
+// is returned at index i, and is nil if and only if that share is valid, so
+// that invalid shares can be attributed to their senders.
+func VerifyPartials(suite pairing.Suite, public *share.PubPoly, msg []byte, partials [][]byte) []error {
+	errs := make([]error, len(partials))
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > len(partials) {
+		workers = len(partials)
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = Verify(suite, public, msg, partials[i])
+			}
+		}()
+	}
+	for i := range partials {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
+
 // Recover reconstructs the full BLS signature S = x * H(m) from a threshold t
-// of signature shares Si using Lagrange interpolation. The full signature S
-// can be verified through the regular BLS verification routine using the
-// shared public key X. The shared public key can be computed by evaluating the
-// public sharing polynomial at index 0.
+// of signature shares Si using Lagrange interpolation. Each share is verified
+// before being used, and the recovered signature S is verified against the
+// shared public key X before being returned, so that S can be used as is, for
+// example as the output of a randomness beacon. The full signature S can be
+// verified through the regular BLS verification routine using the shared
+// public key X. The shared public key can be computed by evaluating the
+// public sharing polynomial at index 0. An error is returned if fewer than t
+// shares are given.
 func Recover(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte, t, n int) ([]byte, error) {
 	pubShares := make([]*share.PubShare, 0)
 	for _, sig := range sigs {
@@ -103,5 +159,32 @@ func Recover(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]by
 	if err != nil {
 		return nil, err
 	}
+	if err := bls.Verify(suite, public.Commit(), msg, sig); err != nil {
+		return nil, err
+	}
 	return sig, nil
 }
+
+// EnoughShares reports whether sigs holds valid signature shares on msg from
+// at least public.Threshold() distinct signers, i.e. whether Recover can
+// succeed with them. Shares with an invalid encoding or signature are
+// ignored, and several shares with the same index count once.
+func EnoughShares(suite pairing.Suite, public *share.PubPoly, msg []byte, sigs [][]byte) bool {
+	t := public.Threshold()
+	valid := make(map[int]bool)
+	for _, sig := range sigs {
+		s := SigShare(sig)
+		i, err := s.Index()
+		if err != nil || valid[i] {
+			continue
+		}
+		if Verify(suite, public, msg, sig) != nil {
+			continue
+		}
+		valid[i] = true
+		if len(valid) >= t {
+			return true
+		}
+	}
+	return false
+}
@@ -4,8 +4,11 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 	"go.dedis.ch/kyber/v3/share"
+	dkg "go.dedis.ch/kyber/v3/share/dkg/pedersen"
 	"go.dedis.ch/kyber/v3/sign/bls"
 )
 
@@ -29,3 +32,216 @@ func TestTBLS(test *testing.T) {
 	err = bls.Verify(suite, pubPoly.Commit(), msg, sig)
 	require.Nil(test, err)
 }
+
+func TestTBLSRecoverNotEnough(test *testing.T) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	n := 10
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	sigShares := make([][]byte, 0)
+	for _, x := range priPoly.Shares(n) {
+		sig, err := Sign(suite, x, msg)
+		require.Nil(test, err)
+		sigShares = append(sigShares, sig)
+	}
+
+	sig, err := Recover(suite, pubPoly, msg, sigShares[n-t:], t, n)
+	require.NoError(test, err)
+	require.NoError(test, bls.Verify(suite, pubPoly.Commit(), msg, sig))
+
+	sig, err = Recover(suite, pubPoly, msg, sigShares[:t-1], t, n)
+	require.Error(test, err)
+	require.Nil(test, sig)
+
+	// shares of another message are refused
+	sig, err = Recover(suite, pubPoly, []byte("another message"), sigShares, t, n)
+	require.Error(test, err)
+	require.Nil(test, sig)
+}
+
+func TestTBLSEnoughShares(test *testing.T) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	n := 10
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	sigShares := make([][]byte, 0)
+	for _, x := range priPoly.Shares(n) {
+		sig, err := Sign(suite, x, msg)
+		require.Nil(test, err)
+		sigShares = append(sigShares, sig)
+	}
+
+	require.True(test, EnoughShares(suite, pubPoly, msg, sigShares))
+	require.True(test, EnoughShares(suite, pubPoly, msg, sigShares[:t]))
+	require.False(test, EnoughShares(suite, pubPoly, msg, sigShares[:t-1]))
+	require.False(test, EnoughShares(suite, pubPoly, []byte("other"), sigShares))
+
+	// duplicates of the same share are counted once
+	dup := append([][]byte{}, sigShares[:t-1]...)
+	dup = append(dup, sigShares[0], sigShares[1])
+	require.False(test, EnoughShares(suite, pubPoly, msg, dup))
+
+	// an invalid share does not count
+	bad := append([][]byte{}, sigShares[:t-1]...)
+	bad = append(bad, sigShares[t][:len(sigShares[t])-1])
+	require.False(test, EnoughShares(suite, pubPoly, msg, bad))
+}
+
+func TestTBLSVerifyPartials(test *testing.T) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	n := 10
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	sigShares := make([][]byte, 0)
+	for _, x := range priPoly.Shares(n) {
+		sig, err := Sign(suite, x, msg)
+		require.Nil(test, err)
+		sigShares = append(sigShares, sig)
+	}
+
+	// share 2 signs another message, share 5 claims the index of share 6
+	// and share 7 is truncated
+	other, err := Sign(suite, priPoly.Eval(2), []byte("another message"))
+	require.NoError(test, err)
+	sigShares[2] = other
+	sigShares[5] = append(append([]byte{}, sigShares[6][:2]...), sigShares[5][2:]...)
+	sigShares[7] = sigShares[7][:1]
+
+	errs := VerifyPartials(suite, pubPoly, msg, sigShares)
+	require.Len(test, errs, n)
+	for i, err := range errs {
+		switch i {
+		case 2, 5, 7:
+			require.Error(test, err, i)
+		default:
+			require.NoError(test, err, i)
+		}
+	}
+
+	require.Empty(test, VerifyPartials(suite, pubPoly, msg, nil))
+}
+
+func BenchmarkTBLSVerifyPartials(b *testing.B) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	n := 100
+	t := n/2 + 1
+	priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+	pubPoly := priPoly.Commit(suite.G2().Point().Base())
+	sigShares := make([][]byte, 0)
+	for _, x := range priPoly.Shares(n) {
+		sig, err := Sign(suite, x, msg)
+		require.Nil(b, err)
+		sigShares = append(sigShares, sig)
+	}
+
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, sig := range sigShares {
+				_ = Verify(suite, pubPoly, msg, sig)
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			VerifyPartials(suite, pubPoly, msg, sigShares)
+		}
+	})
+}
+
+func TestTBLSSignerFromDKG(test *testing.T) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	suite := bn256.NewSuite()
+	n := 5
+	t := n/2 + 1
+
+	// run a DKG on G2, the group of the public keys
+	g2 := bn256.NewSuiteG2()
+	secrets := make([]kyber.Scalar, n)
+	publics := make([]kyber.Point, n)
+	for i := range secrets {
+		secrets[i] = g2.Scalar().Pick(g2.RandomStream())
+		publics[i] = g2.Point().Mul(secrets[i], nil)
+	}
+	dkgs := make([]*dkg.DistKeyGenerator, n)
+	for i := range dkgs {
+		d, err := dkg.NewDistKeyGenerator(g2, secrets[i], publics, t)
+		require.NoError(test, err)
+		dkgs[i] = d
+	}
+	var resps []*dkg.Response
+	for _, d := range dkgs {
+		deals, err := d.Deals()
+		require.NoError(test, err)
+		for i, deal := range deals {
+			resp, err := dkgs[i].ProcessDeal(deal)
+			require.NoError(test, err)
+			resps = append(resps, resp)
+		}
+	}
+	for _, resp := range resps {
+		for i, d := range dkgs {
+			if resp.Response.Index == uint32(i) {
+				continue
+			}
+			_, err := d.ProcessResponse(resp)
+			require.NoError(test, err)
+		}
+	}
+
+	var pubPoly *share.PubPoly
+	sigShares := make([][]byte, 0, n)
+	for _, d := range dkgs {
+		res, err := d.Result()
+		require.NoError(test, err)
+		pubPoly = share.NewPubPoly(g2, nil, res.Key.Commitments())
+
+		sig, err := NewSigner(suite, res.Key.PriShare()).Sign(msg)
+		require.NoError(test, err)
+		require.NoError(test, Verify(suite, pubPoly, msg, sig))
+		sigShares = append(sigShares, sig)
+	}
+
+	sig, err := Recover(suite, pubPoly, msg, sigShares[1:], t, n)
+	require.NoError(test, err)
+	require.NoError(test, bls.Verify(suite, pubPoly.Commit(), msg, sig))
+}
+
+func TestTBLSKeyPlacement(test *testing.T) {
+	msg := []byte("Hello threshold Boneh-Lynn-Shacham")
+	n := 10
+	t := n/2 + 1
+	for _, suite := range []pairing.Suite{
+		// public keys on G2 and signatures on G1
+		bn256.NewSuite(),
+		// public keys on G1 and signatures on G2
+		pairing.Swap(bn256.NewSuite()),
+	} {
+		priPoly := share.NewPriPoly(suite.G2(), t, nil, suite.RandomStream())
+		pubPoly := priPoly.Commit(suite.G2().Point().Base())
+		sigShares := make([][]byte, 0)
+		for _, x := range priPoly.Shares(n) {
+			sig, err := Sign(suite, x, msg)
+			require.NoError(test, err)
+			require.Len(test, sig, 2+suite.G1().PointLen())
+			require.NoError(test, Verify(suite, pubPoly, msg, sig))
+			require.Error(test, Verify(suite, pubPoly, []byte("other"), sig))
+			sigShares = append(sigShares, sig)
+		}
+
+		// a share verified with the public share of another index
+		wrong := append([]byte{}, sigShares[1]...)
+		copy(wrong[:2], sigShares[2][:2])
+		require.Error(test, Verify(suite, pubPoly, msg, wrong))
+
+		sig, err := Recover(suite, pubPoly, msg, sigShares, t, n)
+		require.NoError(test, err)
+		require.NoError(test, bls.Verify(suite, pubPoly.Commit(), msg, sig))
+	}
+}
@@ -0,0 +1,81 @@
+package sign
+
+import (
+	"encoding/binary"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Scheme is a signature scheme over raw messages, such as BLS or Schnorr
+// bound to a suite.
+type Scheme interface {
+	Sign(private kyber.Scalar, msg []byte) ([]byte, error)
+	Verify(public kyber.Point, msg, sig []byte) error
+}
+
+// TranscriptSigner signs a list of labeled fields under a domain separation
+// tag. The fields are serialized with their lengths, so that two transcripts
+// which only differ in how the same bytes are split into fields produce
+// different messages.
+type TranscriptSigner struct {
+	scheme Scheme
+	domain string
+	fields []transcriptField
+}
+
+type transcriptField struct {
+	label string
+	value []byte
+}
+
+// NewTranscriptSigner returns an empty transcript signed with scheme under
+// the given domain.
+func NewTranscriptSigner(scheme Scheme, domain string) *TranscriptSigner {
+	return &TranscriptSigner{
+		scheme: scheme,
+		domain: domain,
+	}
+}
+
+// AddField appends a labeled field to the transcript. The order of the
+// fields is part of the signed message.
+func (t *TranscriptSigner) AddField(label string, value []byte) *TranscriptSigner {
+	v := make([]byte, len(value))
+	copy(v, value)
+	t.fields = append(t.fields, transcriptField{label, v})
+	return t
+}
+
+// Message returns the serialization of the transcript that is signed: the
+// domain, the number of fields and each label and value, all prefixed by
+// their length as a 64-bit big-endian integer.
+func (t *TranscriptSigner) Message() []byte {
+	var msg []byte
+	msg = appendBytes(msg, []byte(t.domain))
+	msg = appendUint64(msg, uint64(len(t.fields)))
+	for _, f := range t.fields {
+		msg = appendBytes(msg, []byte(f.label))
+		msg = appendBytes(msg, f.value)
+	}
+	return msg
+}
+
+// Sign signs the transcript with the private key.
+func (t *TranscriptSigner) Sign(private kyber.Scalar) ([]byte, error) {
+	return t.scheme.Sign(private, t.Message())
+}
+
+// Verify checks the signature of the transcript against the public key.
+func (t *TranscriptSigner) Verify(public kyber.Point, sig []byte) error {
+	return t.scheme.Verify(public, t.Message(), sig)
+}
+
+func appendUint64(b []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendBytes(b, v []byte) []byte {
+	return append(appendUint64(b, uint64(len(v))), v...)
+}
@@ -0,0 +1,59 @@
+package sign
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/pairing"
+	"go.dedis.ch/kyber/v3/sign/bls"
+	"go.dedis.ch/kyber/v3/util/random"
+)
+
+type blsScheme struct {
+	suite pairing.Suite
+}
+
+func (s blsScheme) Sign(private kyber.Scalar, msg []byte) ([]byte, error) {
+	return bls.Sign(s.suite, private, msg)
+}
+
+func (s blsScheme) Verify(public kyber.Point, msg, sig []byte) error {
+	return bls.Verify(s.suite, public, msg, sig)
+}
+
+func TestTranscriptSigner(t *testing.T) {
+	scheme := blsScheme{suite}
+	private, public := bls.NewKeyPair(suite, random.New())
+
+	tr := NewTranscriptSigner(scheme, "test").
+		AddField("from", []byte("alice")).
+		AddField("to", []byte("bob"))
+	sig, err := tr.Sign(private)
+	require.NoError(t, err)
+	require.NoError(t, tr.Verify(public, sig))
+
+	same := NewTranscriptSigner(scheme, "test").
+		AddField("from", []byte("alice")).
+		AddField("to", []byte("bob"))
+	require.NoError(t, same.Verify(public, sig))
+
+	// the same bytes split differently across the fields
+	regrouped := NewTranscriptSigner(scheme, "test").
+		AddField("from", []byte("alicet")).
+		AddField("o", []byte("bob"))
+	require.Error(t, regrouped.Verify(public, sig))
+	merged := NewTranscriptSigner(scheme, "test").
+		AddField("from", []byte("alicetobob"))
+	require.Error(t, merged.Verify(public, sig))
+
+	otherDomain := NewTranscriptSigner(scheme, "other").
+		AddField("from", []byte("alice")).
+		AddField("to", []byte("bob"))
+	require.Error(t, otherDomain.Verify(public, sig))
+
+	reordered := NewTranscriptSigner(scheme, "test").
+		AddField("to", []byte("bob")).
+		AddField("from", []byte("alice"))
+	require.Error(t, reordered.Verify(public, sig))
+}
@@ -1,8 +1,10 @@
 package suites
 
 import (
+	"go.dedis.ch/kyber/v3/group/ed448"
 	"go.dedis.ch/kyber/v3/group/edwards25519"
 	"go.dedis.ch/kyber/v3/group/nist"
+	"go.dedis.ch/kyber/v3/group/secp256k1"
 	"go.dedis.ch/kyber/v3/pairing"
 	"go.dedis.ch/kyber/v3/pairing/bn256"
 )
@@ -16,6 +18,11 @@ func init() {
 	register(bn256.NewSuiteG2())
 	register(bn256.NewSuiteGT())
 	register(pairing.NewSuiteBn256())
+	register(ed448.NewBlakeSHA512Ed448())
+	// The curve used by Bitcoin and Ethereum. Its point arithmetic is
+	// constant time but its scalars are not, so it is not accepted by
+	// RequireConstantTime
+	register(secp256k1.NewBlakeSHA256Secp256k1())
 	// This is a constant time implementation that should be
 	// used as much as possible
 	register(edwards25519.NewBlakeSHA256Ed25519())
@@ -0,0 +1,22 @@
+package suites
+
+import (
+	"bytes"
+
+	"go.dedis.ch/kyber/v3/util/encoding"
+)
+
+// Fingerprint returns a digest identifying the on-wire parameters of the
+// given suite: the group name, the scalar and point lengths and the
+// marshalled base point. Two suites with the same fingerprint produce
+// interoperable encodings, so peers can exchange it during a handshake to
+// detect a mismatch before any other message is sent.
+func Fingerprint(s Suite) []byte {
+	return encoding.Fingerprint(s)
+}
+
+// Compatible returns true if both suites have the same fingerprint, i.e.
+// if points and scalars marshalled with one can be read by the other.
+func Compatible(a, b Suite) bool {
+	return bytes.Equal(Fingerprint(a), Fingerprint(b))
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
 )
 
 func TestSuites_Find(t *testing.T) {
@@ -14,6 +15,7 @@ func TestSuites_Find(t *testing.T) {
 		"bn256.GT",
 		"P256",
 		"Residue512",
+		"Ed448",
 	}
 
 	for _, name := range ss {
@@ -38,3 +40,15 @@ func TestSuites_ConstTime(t *testing.T) {
 	require.NoError(t, err)
 	require.NotNil(t, s)
 }
+
+func TestSuites_Compatible(t *testing.T) {
+	ed1 := MustFind("ed25519")
+	ed2 := edwards25519.NewBlakeSHA256Ed25519()
+	p256 := MustFind("P256")
+
+	require.True(t, Compatible(ed1, ed2))
+	require.Equal(t, Fingerprint(ed1), Fingerprint(ed2))
+	require.False(t, Compatible(ed1, p256))
+	require.NotEqual(t, Fingerprint(ed1), Fingerprint(p256))
+	require.False(t, Compatible(MustFind("bn256.G1"), MustFind("bn256.G2")))
+}
@@ -34,10 +34,15 @@ func WriteHexPoint(group kyber.Group, w io.Writer, point kyber.Point) error {
 }
 
 // ReadHexScalar takes a hex-encoded scalar and returns that scalar,
-// optionally an error
+// optionally an error. Since scalars are often private keys, the hex string
+// is decoded in constant time.
 func ReadHexScalar(group kyber.Group, r io.Reader) (kyber.Scalar, error) {
 	s := group.Scalar()
-	buf, err := getHex(r, s.MarshalSize())
+	bufHex, err := readHex(r, s.MarshalSize())
+	if err != nil {
+		return nil, err
+	}
+	buf, err := DecodeSecretHex(string(bufHex))
 	if err != nil {
 		return nil, err
 	}
@@ -78,9 +83,48 @@ func StringHexToScalar(group kyber.Group, str string) (kyber.Scalar, error) {
 	return ReadHexScalar(group, strings.NewReader(str))
 }
 
-func getHex(r io.Reader, l int) ([]byte, error) {
+// DecodeSecretHex decodes the hexadecimal string s in time that only depends
+// on its length, so that it can be used to load private keys and other
+// secrets. The standard encoding/hex decoder branches and indexes tables on
+// the value of each character, and remains the right choice for public data
+// such as points. On invalid input, the returned error does not tell which
+// character is invalid.
+func DecodeSecretHex(s string) ([]byte, error) {
+	if len(s)%2 != 0 {
+		return nil, errors.New("encoding: odd length hex string")
+	}
+	out := make([]byte, len(s)/2)
+	var valid int32 = -1
+	for i := range out {
+		hi, okHi := hexNibble(s[2*i])
+		lo, okLo := hexNibble(s[2*i+1])
+		out[i] = hi<<4 | lo
+		valid &= okHi & okLo
+	}
+	if valid == 0 {
+		return nil, errors.New("encoding: invalid hex character")
+	}
+	return out, nil
+}
+
+// hexNibble returns the value of the hex digit c, and a mask that is -1 if c
+// is a valid digit and 0 otherwise, without branching on c.
+func hexNibble(c byte) (byte, int32) {
+	x := int32(c)
+	isDigit := inRange(x, '0', '9')
+	isLower := inRange(x, 'a', 'f')
+	isUpper := inRange(x, 'A', 'F')
+	v := (isDigit & (x - '0')) | (isLower & (x - 'a' + 10)) | (isUpper & (x - 'A' + 10))
+	return byte(v), isDigit | isLower | isUpper
+}
+
+// inRange returns -1 if lo <= x <= hi and 0 otherwise, for x in [0, 255].
+func inRange(x, lo, hi int32) int32 {
+	return ((lo - 1 - x) & (x - hi - 1)) >> 31
+}
+
+func readHex(r io.Reader, l int) ([]byte, error) {
 	bufHex := make([]byte, l*2)
-	bufByte := make([]byte, l)
 	n, err := r.Read(bufHex)
 	if err != nil {
 		return nil, err
@@ -88,6 +132,15 @@ func getHex(r io.Reader, l int) ([]byte, error) {
 	if n < len(bufHex) {
 		return nil, errors.New("didn't get enough bytes from stream")
 	}
+	return bufHex, nil
+}
+
+func getHex(r io.Reader, l int) ([]byte, error) {
+	bufHex, err := readHex(r, l)
+	if err != nil {
+		return nil, err
+	}
+	bufByte := make([]byte, l)
 	_, err = hex.Decode(bufByte, bufHex)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,29 @@
+//go:build go1.18
+// +build go1.18
+
+package encoding
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// FuzzDecodeSecretHex checks that DecodeSecretHex never panics and accepts
+// exactly the inputs accepted by encoding/hex, with the same result.
+func FuzzDecodeSecretHex(f *testing.F) {
+	f.Add("")
+	f.Add("00ff")
+	f.Add("0g")
+	f.Add("abc")
+	f.Fuzz(func(t *testing.T, s string) {
+		out, err := DecodeSecretHex(s)
+		want, wantErr := hex.DecodeString(s)
+		if (err == nil) != (wantErr == nil) {
+			t.Fatalf("%q: got error %v, encoding/hex returned %v", s, err, wantErr)
+		}
+		if err == nil && !bytes.Equal(out, want) {
+			t.Fatalf("%q: got %x, want %x", s, out, want)
+		}
+	})
+}
@@ -98,3 +98,25 @@ func TestReadHexPointErrorNotEnoughBytes(t *testing.T) {
 	require.Error(t, err, "Expected error when not enough bytes from stream, but got nil")
 	require.EqualError(t, err, "didn't get enough bytes from stream", "Expected error message: didn't get enough bytes from stream, but got %s", err.Error())
 }
+
+func TestDecodeSecretHex(t *testing.T) {
+	for _, v := range []struct {
+		in  string
+		out []byte
+	}{
+		{"", []byte{}},
+		{"00", []byte{0}},
+		{"0123456789abcdef", []byte{0x01, 0x23, 0x45, 0x67, 0x89, 0xab, 0xcd, 0xef}},
+		{"ABCDEF", []byte{0xab, 0xcd, 0xef}},
+		{"fF", []byte{0xff}},
+	} {
+		out, err := DecodeSecretHex(v.in)
+		require.NoError(t, err)
+		require.Equal(t, v.out, out)
+	}
+
+	for _, in := range []string{"0", "abc", "0g", "g0", "zz", "0x", "/0", ":0", "@0", "G0", "`0", " 00 "} {
+		_, err := DecodeSecretHex(in)
+		require.Error(t, err, in)
+	}
+}
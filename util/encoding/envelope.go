@@ -0,0 +1,129 @@
+package encoding
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+
+	"go.dedis.ch/kyber/v3"
+)
+
+// Tag identifies the type of the object held in an Envelope.
+type Tag byte
+
+// Types of objects an Envelope can hold. TagBytes is used for opaque byte
+// strings such as signatures.
+const (
+	TagPoint Tag = iota + 1
+	TagScalar
+	TagBytes
+)
+
+// fingerprintSize is the length of Fingerprint.
+const fingerprintSize = sha256.Size
+
+// Fingerprint returns a digest identifying the on-wire parameters of the
+// given group: its name, the scalar and point lengths and the marshalled
+// base point. Two groups with the same fingerprint produce interoperable
+// encodings. suites.Fingerprint returns it for a suite.
+func Fingerprint(g kyber.Group) []byte {
+	h := sha256.New()
+	name := []byte(g.String())
+	_ = binary.Write(h, binary.BigEndian, uint32(len(name)))
+	_, _ = h.Write(name)
+	_ = binary.Write(h, binary.BigEndian, uint32(g.ScalarLen()))
+	_ = binary.Write(h, binary.BigEndian, uint32(g.PointLen()))
+	if _, err := g.Point().Base().MarshalTo(h); err != nil {
+		panic(err)
+	}
+	return h.Sum(nil)
+}
+
+// Envelope is a self-describing encoding of a kyber object: the fingerprint
+// of the suite it belongs to, a tag giving its type, and its binary encoding.
+// It is marshalled as "fingerprint || tag || data".
+type Envelope struct {
+	Fingerprint []byte
+	Tag         Tag
+	Data        []byte
+}
+
+// MarshalBinary returns the encoding of the envelope.
+func (e *Envelope) MarshalBinary() ([]byte, error) {
+	if len(e.Fingerprint) != fingerprintSize {
+		return nil, errors.New("encoding: invalid envelope fingerprint")
+	}
+	buf := make([]byte, 0, fingerprintSize+1+len(e.Data))
+	buf = append(buf, e.Fingerprint...)
+	buf = append(buf, byte(e.Tag))
+	return append(buf, e.Data...), nil
+}
+
+// UnmarshalBinary reads an envelope encoded by MarshalBinary. It does not
+// check the fingerprint nor the data, see UnmarshalEnvelope.
+func (e *Envelope) UnmarshalBinary(buf []byte) error {
+	if len(buf) < fingerprintSize+1 {
+		return errors.New("encoding: envelope too short")
+	}
+	e.Fingerprint = append([]byte{}, buf[:fingerprintSize]...)
+	e.Tag = Tag(buf[fingerprintSize])
+	e.Data = append([]byte{}, buf[fingerprintSize+1:]...)
+	return nil
+}
+
+// MarshalEnvelope returns the envelope encoding of obj, which must be a
+// kyber.Point, a kyber.Scalar or a []byte, tagged with the fingerprint of
+// suite.
+func MarshalEnvelope(suite kyber.Group, obj interface{}) ([]byte, error) {
+	e := &Envelope{Fingerprint: Fingerprint(suite)}
+	var err error
+	switch o := obj.(type) {
+	case kyber.Point:
+		e.Tag = TagPoint
+		e.Data, err = o.MarshalBinary()
+	case kyber.Scalar:
+		e.Tag = TagScalar
+		e.Data, err = o.MarshalBinary()
+	case []byte:
+		e.Tag = TagBytes
+		e.Data = o
+	default:
+		return nil, errors.New("encoding: unsupported envelope object")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return e.MarshalBinary()
+}
+
+// UnmarshalEnvelope decodes an envelope created by MarshalEnvelope. It
+// returns an error if the envelope was made with a suite whose fingerprint
+// differs from the one of suite. Otherwise it returns a kyber.Point, a
+// kyber.Scalar or a []byte according to the tag of the envelope.
+func UnmarshalEnvelope(suite kyber.Group, buf []byte) (interface{}, error) {
+	e := new(Envelope)
+	if err := e.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(e.Fingerprint, Fingerprint(suite)) {
+		return nil, errors.New("encoding: envelope from another suite")
+	}
+	switch e.Tag {
+	case TagPoint:
+		p := suite.Point()
+		if err := p.UnmarshalBinary(e.Data); err != nil {
+			return nil, err
+		}
+		return p, nil
+	case TagScalar:
+		s := suite.Scalar()
+		if err := s.UnmarshalBinary(e.Data); err != nil {
+			return nil, err
+		}
+		return s, nil
+	case TagBytes:
+		return e.Data, nil
+	}
+	return nil, errors.New("encoding: unknown envelope tag")
+}
@@ -0,0 +1,51 @@
+package encoding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/group/nist"
+	"go.dedis.ch/kyber/v3/sign/schnorr"
+)
+
+func TestEnvelope(t *testing.T) {
+	s := edwards25519.NewBlakeSHA256Ed25519()
+	p := s.Point().Pick(s.RandomStream())
+	buf, err := MarshalEnvelope(s, p)
+	require.NoError(t, err)
+	obj, err := UnmarshalEnvelope(s, buf)
+	require.NoError(t, err)
+	require.True(t, p.Equal(obj.(kyber.Point)))
+
+	x := s.Scalar().Pick(s.RandomStream())
+	buf, err = MarshalEnvelope(s, x)
+	require.NoError(t, err)
+	obj, err = UnmarshalEnvelope(s, buf)
+	require.NoError(t, err)
+	require.True(t, x.Equal(obj.(kyber.Scalar)))
+
+	msg := []byte("sealed")
+	sig, err := schnorr.Sign(s, x, msg)
+	require.NoError(t, err)
+	buf, err = MarshalEnvelope(s, sig)
+	require.NoError(t, err)
+	obj, err = UnmarshalEnvelope(s, buf)
+	require.NoError(t, err)
+	require.Equal(t, sig, obj.([]byte))
+	require.NoError(t, schnorr.Verify(s, s.Point().Mul(x, nil), msg, obj.([]byte)))
+
+	// wrong suite
+	_, err = UnmarshalEnvelope(nist.NewBlakeSHA256P256(), buf)
+	require.Error(t, err)
+
+	// corrupted envelopes
+	_, err = UnmarshalEnvelope(s, buf[:fingerprintSize])
+	require.Error(t, err)
+	buf[fingerprintSize] = 0
+	_, err = UnmarshalEnvelope(s, buf)
+	require.Error(t, err)
+	_, err = MarshalEnvelope(s, "not a kyber object")
+	require.Error(t, err)
+}
@@ -3,8 +3,10 @@ package key
 
 import (
 	"crypto/cipher"
+	"errors"
 
 	"go.dedis.ch/kyber/v3"
+	"go.dedis.ch/kyber/v3/util/random"
 )
 
 // Generator is a type that needs to implement a special case in order
@@ -33,13 +35,83 @@ func NewKeyPair(suite Suite) *Pair {
 	return kp
 }
 
+// NewKeyPairs creates n secret/public key pairs, drawing all the private
+// keys from a single random stream of the suite instead of opening a new
+// stream for each key.
+func NewKeyPairs(suite Suite, n int) []*Pair {
+	stream := suite.RandomStream()
+	pairs := make([]*Pair, n)
+	for i := range pairs {
+		pairs[i] = new(Pair)
+		pairs[i].gen(suite, stream)
+	}
+	return pairs
+}
+
+// NewCheckedKeyPair creates a secret/public key pair like NewKeyPair, but
+// first runs random.HealthCheck on the suite's random stream and returns an
+// error instead of a key pair if the check fails. The same stream is then
+// used to generate the key.
+func NewCheckedKeyPair(suite Suite) (*Pair, error) {
+	stream := suite.RandomStream()
+	if err := random.HealthCheck(stream); err != nil {
+		return nil, err
+	}
+	kp := new(Pair)
+	kp.gen(suite, stream)
+	return kp, nil
+}
+
+// Scheme is a signature scheme whose keys are the ones of a Pair, such as
+// sign/scheme.Signer.
+type Scheme interface {
+	Sign(private kyber.Scalar, msg []byte) ([]byte, error)
+	Verify(public kyber.Point, msg, sig []byte) error
+}
+
+// consistencyMessage is the message signed by PairwiseConsistencyCheck.
+var consistencyMessage = []byte("kyber key pair consistency test")
+
+// ErrInconsistentPair is returned by PairwiseConsistencyCheck when the
+// private and public keys of a pair do not match.
+var ErrInconsistentPair = errors.New("key: pairwise consistency check failed")
+
+// PairwiseConsistencyCheck signs a fixed message with the private key of
+// the pair and verifies the signature with its public key, as FIPS 140
+// requires before a new key pair is used. It returns ErrInconsistentPair if
+// the verification fails.
+func PairwiseConsistencyCheck(pair *Pair, scheme Scheme) error {
+	sig, err := scheme.Sign(pair.Private, consistencyMessage)
+	if err != nil {
+		return err
+	}
+	if scheme.Verify(pair.Public, consistencyMessage, sig) != nil {
+		return ErrInconsistentPair
+	}
+	return nil
+}
+
+// NewConsistentKeyPair creates a secret/public key pair like NewKeyPair and
+// returns it only if it passes PairwiseConsistencyCheck with scheme, which
+// must use the group of suite.
+func NewConsistentKeyPair(suite Suite, scheme Scheme) (*Pair, error) {
+	kp := NewKeyPair(suite)
+	if err := PairwiseConsistencyCheck(kp, scheme); err != nil {
+		return nil, err
+	}
+	return kp, nil
+}
+
 // Gen creates a fresh public/private keypair with the given
 // ciphersuite, using a given source of cryptographic randomness. If
 // suite implements key.Generator, then suite.NewKey is called
 // to generate the private key, otherwise the normal technique
 // of choosing a random scalar from the group is used.
 func (p *Pair) Gen(suite Suite) {
-	random := suite.RandomStream()
+	p.gen(suite, suite.RandomStream())
+}
+
+func (p *Pair) gen(suite Suite, random cipher.Stream) {
 	if g, ok := suite.(Generator); ok {
 		p.Private = g.NewKey(random)
 	} else {
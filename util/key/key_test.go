@@ -4,8 +4,10 @@ import (
 	"crypto/cipher"
 	"testing"
 
+	"github.com/stretchr/testify/require"
 	"go.dedis.ch/kyber/v3"
 	"go.dedis.ch/kyber/v3/group/edwards25519"
+	"go.dedis.ch/kyber/v3/sign/scheme"
 )
 
 func TestNewKeyPair(t *testing.T) {
@@ -37,3 +39,76 @@ func TestNewKeyPairGen(t *testing.T) {
 		t.Fatalf("expected fixed private key, got %v", key.Private)
 	}
 }
+
+// A suite whose random stream is stuck on a single byte.
+type stuckRandSuiteEd25519 struct {
+	edwards25519.SuiteEd25519
+}
+
+func (s *stuckRandSuiteEd25519) RandomStream() cipher.Stream { return stuckStream{} }
+
+type stuckStream struct{}
+
+func (stuckStream) XORKeyStream(dst, src []byte) { copy(dst, src) }
+
+func TestNewCheckedKeyPair(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	keypair, err := NewCheckedKeyPair(suite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !suite.Point().Mul(keypair.Private, nil).Equal(keypair.Public) {
+		t.Fatal("Public and private keys don't match")
+	}
+
+	if _, err := NewCheckedKeyPair(&stuckRandSuiteEd25519{}); err == nil {
+		t.Fatal("key generation with a stuck random stream should fail")
+	}
+}
+
+func TestNewKeyPairs(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	pairs := NewKeyPairs(suite, 50)
+	if len(pairs) != 50 {
+		t.Fatalf("expected 50 key pairs, got %d", len(pairs))
+	}
+	seen := make(map[string]bool)
+	for _, kp := range pairs {
+		if !suite.Point().Mul(kp.Private, nil).Equal(kp.Public) {
+			t.Fatal("Public and private keys don't match")
+		}
+		if seen[kp.Public.String()] {
+			t.Fatal("duplicate key pair")
+		}
+		seen[kp.Public.String()] = true
+	}
+}
+
+func BenchmarkNewKeyPairs(b *testing.B) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	const n = 100
+	b.Run("batch", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewKeyPairs(suite, n)
+		}
+	})
+	b.Run("separate", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for j := 0; j < n; j++ {
+				NewKeyPair(suite)
+			}
+		}
+	})
+}
+
+func TestPairwiseConsistencyCheck(t *testing.T) {
+	suite := edwards25519.NewBlakeSHA256Ed25519()
+	signer := scheme.New(suite)
+	kp, err := NewConsistentKeyPair(suite, signer)
+	require.NoError(t, err)
+	require.NoError(t, PairwiseConsistencyCheck(kp, signer))
+
+	// a corrupted private key no longer matches the public key
+	broken := &Pair{Public: kp.Public, Private: suite.Scalar().Add(kp.Private, suite.Scalar().One())}
+	require.Equal(t, ErrInconsistentPair, PairwiseConsistencyCheck(broken, signer))
+}
@@ -0,0 +1,79 @@
+package random
+
+import (
+	"crypto/cipher"
+	"errors"
+)
+
+// Parameters of the continuous health tests of NIST SP 800-90B, section
+// 4.4, applied to byte-sized samples under the conservative assumption of
+// one bit of min-entropy per byte and a false positive rate of 2^-20.
+const (
+	healthSampleSize = 1024
+	// repetitionCutoff is 1 + ceil(20 / H) for H = 1.
+	repetitionCutoff = 21
+	// proportionWindow and proportionCutoff are taken from table 2 of
+	// SP 800-90B for non-binary samples and H = 1.
+	proportionWindow = 512
+	proportionCutoff = 410
+)
+
+// ErrRepetitionCount is returned by HealthCheck when the same byte is
+// produced too many times in a row.
+var ErrRepetitionCount = errors.New("random: repetition count test failed")
+
+// ErrAdaptiveProportion is returned by HealthCheck when a byte value occurs
+// too often within a window of samples.
+var ErrAdaptiveProportion = errors.New("random: adaptive proportion test failed")
+
+// HealthCheck draws a sample from r and runs the repetition count and
+// adaptive proportion tests of NIST SP 800-90B on it. It detects sources
+// that are stuck or heavily biased, it does not prove that r is a good
+// source of randomness. The sample drawn from r is discarded.
+func HealthCheck(r cipher.Stream) error {
+	sample := make([]byte, healthSampleSize)
+	Bytes(sample, r)
+	if !repetitionCount(sample) {
+		return ErrRepetitionCount
+	}
+	if !adaptiveProportion(sample) {
+		return ErrAdaptiveProportion
+	}
+	return nil
+}
+
+// repetitionCount returns false if a value is repeated repetitionCutoff
+// times or more in a row.
+func repetitionCount(sample []byte) bool {
+	count := 1
+	for i := 1; i < len(sample); i++ {
+		if sample[i] != sample[i-1] {
+			count = 1
+			continue
+		}
+		count++
+		if count >= repetitionCutoff {
+			return false
+		}
+	}
+	return true
+}
+
+// adaptiveProportion returns false if, in any window of proportionWindow
+// samples, the first value of the window occurs proportionCutoff times or
+// more.
+func adaptiveProportion(sample []byte) bool {
+	for start := 0; start+proportionWindow <= len(sample); start += proportionWindow {
+		window := sample[start : start+proportionWindow]
+		count := 0
+		for _, b := range window {
+			if b == window[0] {
+				count++
+			}
+		}
+		if count >= proportionCutoff {
+			return false
+		}
+	}
+	return true
+}
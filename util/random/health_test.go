@@ -0,0 +1,41 @@
+package random
+
+import (
+	"testing"
+)
+
+// constantStream always produces the same byte.
+type constantStream byte
+
+func (c constantStream) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		dst[i] = src[i] ^ byte(c)
+	}
+}
+
+// biasedStream produces runs of distinct bytes that are short enough to
+// pass the repetition count test, but with a value that dominates.
+type biasedStream struct{ i int }
+
+func (b *biasedStream) XORKeyStream(dst, src []byte) {
+	for i := range src {
+		v := byte(0)
+		if b.i%16 == 15 {
+			v = byte(b.i)
+		}
+		b.i++
+		dst[i] = src[i] ^ v
+	}
+}
+
+func TestHealthCheck(t *testing.T) {
+	if err := HealthCheck(New()); err != nil {
+		t.Fatalf("good stream should pass the health check: %v", err)
+	}
+	if err := HealthCheck(constantStream(0x42)); err != ErrRepetitionCount {
+		t.Fatalf("constant stream should fail the repetition count test, got %v", err)
+	}
+	if err := HealthCheck(&biasedStream{}); err != ErrAdaptiveProportion {
+		t.Fatalf("biased stream should fail the adaptive proportion test, got %v", err)
+	}
+}
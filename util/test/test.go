@@ -94,6 +94,52 @@ func testPointClone(t *testing.T, g kyber.Group, rand cipher.Stream) {
 	}
 }
 
+// testPointAccumulate checks that the receiver of Add and Sub can be one of
+// their arguments, by comparing a running sum updated in place with one
+// computed into a fresh point at each step.
+func testPointAccumulate(t *testing.T, g kyber.Group, rand cipher.Stream) {
+	acc := g.Point().Null()
+	fresh := g.Point().Null()
+	for i := 0; i < 100; i++ {
+		P := g.Point().Pick(rand)
+		switch i % 3 {
+		case 0:
+			acc.Add(acc, P)
+			fresh = g.Point().Add(fresh, P)
+		case 1:
+			acc.Add(P, acc)
+			fresh = g.Point().Add(P, fresh)
+		case 2:
+			acc.Sub(acc, P)
+			fresh = g.Point().Sub(fresh, P)
+		}
+		if !acc.Equal(fresh) {
+			t.Fatalf("in-place accumulation differs at step %d: %v != %v", i, acc, fresh)
+		}
+	}
+
+	P := g.Point().Pick(rand)
+	double := g.Point().Add(P, P)
+	if !P.Add(P, P).Equal(double) {
+		t.Errorf("P.Add(P, P) differs from 2P")
+	}
+	if !P.Sub(P, P).Equal(g.Point().Null()) {
+		t.Errorf("P.Sub(P, P) is not the null point")
+	}
+
+	// a.Sub(a, b).Add(a, b) must give back a, also when b is the null point
+	for _, b := range []kyber.Point{g.Point().Pick(rand), g.Point().Null()} {
+		a := g.Point().Pick(rand)
+		orig := a.Clone()
+		if !a.Sub(a, b).Add(a, b).Equal(orig) {
+			t.Errorf("a.Sub(a, b).Add(a, b) differs from a")
+		}
+		if !g.Point().Neg(b).Add(b, g.Point().Neg(b)).Equal(g.Point().Null()) {
+			t.Errorf("b + -b is not the null point")
+		}
+	}
+}
+
 func testScalarSet(t *testing.T, g kyber.Group, rand cipher.Stream) {
 	N := 1000
 	zero := g.Scalar().Zero()
@@ -358,6 +404,7 @@ func testGroup(t *testing.T, g kyber.Group, rand cipher.Stream) []kyber.Point {
 
 	testPointSet(t, g, rand)
 	testPointClone(t, g, rand)
+	testPointAccumulate(t, g, rand)
 	testScalarSet(t, g, rand)
 	testScalarClone(t, g, rand)
 